@@ -9,6 +9,8 @@ import (
 
 	"github.com/tvarney/grogue/pkg/drivers/terminal"
 	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/craft"
+	"github.com/tvarney/grogue/pkg/game/creature"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -23,31 +25,73 @@ func main() {
 func run(args []string) error {
 	debug := kingpin.Flag("debug", "enable debug logging").Short('D').Bool()
 	seed := kingpin.Flag("seed", "world random seed").Short('s').Default(strconv.FormatInt(time.Now().Unix(), 10)).Int64()
+	summaries := kingpin.Flag("summary-dir", "directory to write game summaries to").Default(game.DefaultSummaryDir()).String()
+	config := kingpin.Flag("config", "settings file to load and save options with").Default(game.DefaultSettingsPath()).String()
+	keys := kingpin.Flag("keys", "key bindings file to load and save key bindings with").Default(game.DefaultKeymapPath()).String()
+	colors := kingpin.Flag("colors", "color mode to draw with, overriding the settings").Enum(game.ColorModes...)
+	glyphs := kingpin.Flag("glyphs", "glyph set file replacing the built-in set of the same name").ExistingFile()
+	recipes := kingpin.Flag("recipes", "recipe file replacing the built-in recipes").ExistingFile()
+	species := kingpin.Flag("species", "species file replacing the built-in species").ExistingFile()
 	_ = kingpin.Parse()
 
 	driver := terminal.New()
-
+	driver.SetColorMode(*colors)
+	if *glyphs != "" {
+		set, err := terminal.LoadGlyphSet(*glyphs)
+		if err != nil {
+			return err
+		}
+		driver.AddGlyphSet(set)
+	}
+	logfile := ""
 	if *debug {
-		driver.SetLog("debug.log")
+		logfile = "debug.log"
 	}
-
-	if err := driver.Init(); err != nil {
-		return err
+	if err := driver.SetLog(logfile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open log: %v\n", err)
 	}
 
 	log.Printf("Starting term-grogue")
 	app := game.New(*seed)
-
-	driver.Draw(app)
-	for app.Running {
-		switch app.Update(driver.PollAction(app)) {
-		case game.RenderFull:
-			driver.Clear()
-			driver.Draw(app)
-		case game.RenderIncremental:
-			driver.Draw(app)
+	app.SummaryDir = *summaries
+	if *recipes != "" {
+		list, err := craft.LoadRecipes(*recipes)
+		if err != nil {
+			return err
+		}
+		app.Game.Recipes = list
+	}
+	if *species != "" {
+		list, err := creature.LoadSpecies(*species, app.Game.Plans)
+		if err != nil {
+			return err
 		}
+		app.Game.Species = list
 	}
-	driver.Finalize()
-	return nil
+	if *config != "" {
+		// If the settings can't be read, they aren't saved either, so that the
+		// file isn't replaced by the defaults.
+		settings, err := game.LoadSettings(*config)
+		if err != nil {
+			log.Printf("Failed to load settings; changes won't be saved: %v", err)
+		} else {
+			app.SettingsPath = *config
+		}
+		app.Settings = settings
+		app.ApplySettings()
+	}
+	if *keys != "" {
+		keymap, err := game.LoadKeymap(*keys)
+		if err != nil {
+			log.Printf("Failed to load key bindings; changes won't be saved: %v", err)
+		} else {
+			app.KeymapPath = *keys
+		}
+		for _, c := range keymap.Conflicts() {
+			log.Printf("Conflicting key binding: %v", c)
+		}
+		app.Keymap = keymap
+	}
+
+	return game.Run(app, driver)
 }
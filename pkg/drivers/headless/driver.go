@@ -0,0 +1,195 @@
+// Package headless implements a game driver which runs without a terminal,
+// for scripted play and tests.
+//
+// The driver draws with the terminal driver onto a simulated screen, feeding
+// the application actions from a script and capturing each rendered frame.
+package headless
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/tvarney/grogue/pkg/drivers/terminal"
+	"github.com/tvarney/grogue/pkg/game"
+)
+
+// The size of the simulated screen used if none is given.
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 36
+)
+
+// Step is a single step of a script.
+//
+// If Text is set, it is typed into the current text entry menu; otherwise the
+// application is updated with Action.
+type Step struct {
+	Action game.Action
+	Text   string
+}
+
+// Actions returns a script which performs each of the actions in turn.
+func Actions(actions ...game.Action) []Step {
+	steps := make([]Step, len(actions))
+	for i, a := range actions {
+		steps[i] = Step{Action: a}
+	}
+	return steps
+}
+
+// ParseScript reads a script, one step per line.
+//
+// Each line holds an action name as returned by game.Action.String, followed
+// by an optional repeat count, or "type" followed by text to type into a text
+// entry menu. Blank lines and lines starting with '#' are ignored.
+//
+//	menu-select
+//	type Hilde
+//	menu-down 2
+func ParseScript(r io.Reader) ([]Step, error) {
+	steps := []Step{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if text := strings.TrimPrefix(line, "type "); text != line {
+			steps = append(steps, Step{Text: text})
+			continue
+		}
+
+		fields := strings.Fields(line)
+		action, ok := game.ParseAction(fields[0])
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown action %q", n, fields[0])
+		}
+		count := 1
+		if len(fields) > 1 {
+			var err error
+			if count, err = strconv.Atoi(fields[1]); err != nil || count < 1 {
+				return nil, fmt.Errorf("line %d: invalid count %q", n, fields[1])
+			}
+		}
+		for i := 0; i < count; i++ {
+			steps = append(steps, Step{Action: action})
+		}
+	}
+	return steps, scanner.Err()
+}
+
+// Driver is a game.Driver which draws to a simulated screen and takes its
+// actions from a script.
+//
+// The driver draws in true color unless another color mode is set, so that
+// frames hold the exact colors drawn. Once the script runs out, the driver
+// quits the application.
+type Driver struct {
+	// Script holds the steps which haven't been performed yet.
+	Script []Step
+
+	// Frames holds every frame rendered, in order.
+	Frames []Frame
+
+	term   *terminal.Driver
+	screen tcell.SimulationScreen
+	width  int
+	height int
+}
+
+// New returns a new Driver with a screen of the given size which performs
+// the steps of the script.
+func New(width, height int, script []Step) *Driver {
+	d := &Driver{
+		Script: script,
+		term:   terminal.New(),
+		width:  width,
+		height: height,
+	}
+	d.term.SetColorMode(game.ColorTrue)
+	return d
+}
+
+// SetColorMode sets the color mode the driver draws with. If mode is empty,
+// the color mode of the application settings is used.
+func (d *Driver) SetColorMode(mode string) {
+	d.term.SetColorMode(mode)
+}
+
+// Init creates the simulated screen.
+func (d *Driver) Init() error {
+	if d.screen != nil {
+		return nil
+	}
+	s := tcell.NewSimulationScreen("")
+	if err := d.term.InitScreen(s); err != nil {
+		return err
+	}
+	s.SetSize(d.width, d.height)
+	d.term.SetSize(d.width, d.height)
+	d.screen = s
+	return nil
+}
+
+// Finalize releases the simulated screen.
+func (d *Driver) Finalize() {
+	d.term.Finalize()
+	d.screen = nil
+}
+
+// PollAction returns the next action of the script, typing any text steps
+// before it into the current menu.
+//
+// If the script is empty, this returns game.ActionQuit.
+func (d *Driver) PollAction(app *game.Application) game.Action {
+	for len(d.Script) > 0 {
+		step := d.Script[0]
+		d.Script = d.Script[1:]
+		if step.Text == "" {
+			return step.Action
+		}
+		d.Type(app, step.Text)
+	}
+	return game.ActionQuit
+}
+
+// Type types the text into the current menu, if it accepts text.
+func (d *Driver) Type(app *game.Application, text string) {
+	entry, ok := app.GetMenu().(game.TextEntry)
+	if !ok {
+		log.Printf("headless.Driver::Type(): Current menu doesn't accept text")
+		return
+	}
+	for _, r := range text {
+		entry.Insert(r)
+	}
+}
+
+// Render draws the application to the simulated screen and captures the
+// result as a new frame.
+func (d *Driver) Render(app *game.Application, req game.RenderRequest) {
+	d.term.Render(app, req)
+	d.Frames = append(d.Frames, capture(d.screen))
+}
+
+// Update updates the application with the action, renders the result, and
+// returns the current frame.
+//
+// This allows tests to drive the application one action at a time without
+// using Run.
+func (d *Driver) Update(app *game.Application, action game.Action) Frame {
+	if req := app.Update(action); req != game.RenderNoChange {
+		d.Render(app, req)
+	}
+	return d.Frame()
+}
+
+// Frame returns the current contents of the simulated screen.
+func (d *Driver) Frame() Frame {
+	return capture(d.screen)
+}
@@ -0,0 +1,188 @@
+package headless_test
+
+import (
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/drivers/headless"
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+const newGameScript = `
+# Start a new game as Hilde the Miner
+menu-select
+menu-backspace 10
+type Hilde
+menu-select
+menu-down
+menu-select
+menu-down 2
+menu-select
+`
+
+func TestParseScript(t *testing.T) {
+	t.Parallel()
+	steps, err := headless.ParseScript(strings.NewReader(newGameScript))
+	require.NoError(t, err)
+	assert.Equal(t, []headless.Step{
+		{Action: game.ActionMenuSelect},
+		{Action: game.ActionMenuBackspace}, {Action: game.ActionMenuBackspace},
+		{Action: game.ActionMenuBackspace}, {Action: game.ActionMenuBackspace},
+		{Action: game.ActionMenuBackspace}, {Action: game.ActionMenuBackspace},
+		{Action: game.ActionMenuBackspace}, {Action: game.ActionMenuBackspace},
+		{Action: game.ActionMenuBackspace}, {Action: game.ActionMenuBackspace},
+		{Text: "Hilde"},
+		{Action: game.ActionMenuSelect},
+		{Action: game.ActionMenuDown},
+		{Action: game.ActionMenuSelect},
+		{Action: game.ActionMenuDown},
+		{Action: game.ActionMenuDown},
+		{Action: game.ActionMenuSelect},
+	}, steps)
+
+	_, err = headless.ParseScript(strings.NewReader("dance"))
+	assert.EqualError(t, err, `line 1: unknown action "dance"`)
+	_, err = headless.ParseScript(strings.NewReader("wait\nwait x"))
+	assert.EqualError(t, err, `line 2: invalid count "x"`)
+}
+
+func TestDriver(t *testing.T) {
+	t.Parallel()
+	t.Run("menu", func(t *testing.T) {
+		t.Parallel()
+		app := game.New(1)
+		d := headless.New(headless.DefaultWidth, headless.DefaultHeight, headless.Actions(game.ActionMenuDown))
+		require.NoError(t, game.Run(app, d))
+		require.Len(t, d.Frames, 2)
+
+		first := d.Frames[0]
+		assert.Equal(t, headless.DefaultWidth, first.Width)
+		assert.Equal(t, headless.DefaultHeight, first.Height)
+		assert.Equal(t, "Main Menu", strings.TrimSpace(first.Line(0)))
+		x, y, ok := first.Find("* New Game")
+		require.True(t, ok)
+		assert.Equal(t, 2, y)
+		assert.Equal(t, 'N', first.At(x+2, y).Rune)
+		assert.True(t, d.Frames[1].Contains("* Load Game"))
+		assert.False(t, d.Frames[1].Contains("* New Game"))
+	})
+	t.Run("new game", func(t *testing.T) {
+		t.Parallel()
+		steps, err := headless.ParseScript(strings.NewReader(newGameScript))
+		require.NoError(t, err)
+		app := game.New(1)
+		d := headless.New(headless.DefaultWidth, headless.DefaultHeight, steps)
+		require.NoError(t, d.Init())
+		defer d.Finalize()
+		for len(d.Script) > 0 {
+			d.Update(app, d.PollAction(app))
+		}
+		require.True(t, app.InGame)
+
+		frame := d.Frame()
+		assert.True(t, frame.Contains("Welcome to GRogue, Hilde!"))
+		assert.True(t, strings.HasSuffix(frame.Line(0), " Hilde"))
+		// The player is drawn in the map, to the left of the sidebar and above
+		// the status lines.
+		x, y, ok := frame.Find("☺")
+		require.True(t, ok)
+		assert.Less(t, x, headless.DefaultWidth-28)
+		assert.Less(t, y, headless.DefaultHeight-5)
+
+		frame = d.Update(app, game.ActionWait)
+		cell := frame.At(0, lineOf(t, frame, "You wait."))
+		assert.Equal(t, int32(color.BrightGray.Value()), cell.Fg)
+
+		// The side view scrolls horizontally in the same way as the top view.
+		frame = d.Update(app, game.ActionSideView)
+		assert.True(t, frame.Contains("| Side |"))
+		sx, _, ok := frame.Find("☺")
+		require.True(t, ok)
+		assert.Equal(t, x, sx)
+		frame = d.Update(app, game.ActionSideView)
+		assert.True(t, frame.Contains("| Top |"))
+
+		// Colors are mapped to the nearest ANSI color in 16-color mode
+		d.SetColorMode(game.Color16)
+		d.Render(app, game.RenderFull)
+		frame = d.Frame()
+		cell = frame.At(0, lineOf(t, frame, "You wait."))
+		assert.Equal(t, int32(color.XtermValue(color.ANSI16(color.BrightGray.Value()))), cell.Fg)
+	})
+	t.Run("narrow", func(t *testing.T) {
+		t.Parallel()
+		steps, err := headless.ParseScript(strings.NewReader(newGameScript))
+		require.NoError(t, err)
+		app := game.New(1)
+		d := headless.New(50, headless.DefaultHeight, steps)
+		require.NoError(t, d.Init())
+		defer d.Finalize()
+		for len(d.Script) > 0 {
+			d.Update(app, d.PollAction(app))
+		}
+		require.True(t, app.InGame)
+
+		// The sidebar isn't shown, so the map takes the full width
+		frame := d.Frame()
+		assert.False(t, strings.HasSuffix(frame.Line(0), " Hilde"))
+		_, _, ok := frame.Find("☺")
+		assert.True(t, ok)
+
+		frame = d.Update(app, game.ActionLook)
+		assert.True(t, frame.Contains("Look: "))
+	})
+	t.Run("centered options", func(t *testing.T) {
+		t.Parallel()
+		app := game.New(1)
+		app.Game.Message(message.Info, "Ça va, Zoë?")
+		app.PushMenu(game.MessageLogMenuID)
+		d := headless.New(40, 10, nil)
+		require.NoError(t, d.Init())
+		defer d.Finalize()
+		d.Render(app, game.RenderFull)
+
+		// Options are centered by their width in runes
+		frame := d.Frame()
+		x, _, ok := frame.Find("* Ça va, Zoë?")
+		require.True(t, ok)
+		assert.Equal(t, (40-13)/2, x)
+	})
+	t.Run("text entry", func(t *testing.T) {
+		t.Parallel()
+		steps, err := headless.ParseScript(strings.NewReader("menu-select\nmenu-backspace 10\ntype Jürgen\nmenu-left 4"))
+		require.NoError(t, err)
+		app := game.New(1)
+		d := headless.New(headless.DefaultWidth, headless.DefaultHeight, steps)
+		require.NoError(t, d.Init())
+		defer d.Finalize()
+		for len(d.Script) > 0 {
+			d.Update(app, d.PollAction(app))
+		}
+
+		// Names are laid out by rune, with the cursor drawn over the 'r'
+		frame := d.Frame()
+		x, y, ok := frame.Find("Jürgen")
+		require.True(t, ok)
+		assert.Equal(t, 'r', frame.At(x+2, y).Rune)
+		assert.Equal(t, ' ', frame.At(x+6, y).Rune)
+	})
+}
+
+func lineOf(t *testing.T, f headless.Frame, text string) int {
+	t.Helper()
+	_, y, ok := f.Find(text)
+	require.True(t, ok)
+	return y
+}
@@ -0,0 +1,99 @@
+package headless
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell"
+)
+
+// DefaultColor is the color of cells drawn with the terminal's default
+// foreground or background color.
+const DefaultColor int32 = -1
+
+// Cell is a single captured character cell.
+type Cell struct {
+	Rune rune
+
+	// Fg and Bg are the foreground and background colors of the cell, as
+	// 0xRRGGBB values, or DefaultColor.
+	Fg int32
+	Bg int32
+}
+
+// Frame is a captured screen of character cells.
+type Frame struct {
+	Width  int
+	Height int
+	Cells  []Cell
+}
+
+// capture copies the contents of the screen into a new Frame.
+func capture(s tcell.SimulationScreen) Frame {
+	cells, w, h := s.GetContents()
+	f := Frame{Width: w, Height: h, Cells: make([]Cell, len(cells))}
+	for i, c := range cells {
+		fg, bg, _ := c.Style.Decompose()
+		f.Cells[i] = Cell{Rune: ' ', Fg: hex(fg), Bg: hex(bg)}
+		if len(c.Runes) > 0 {
+			f.Cells[i].Rune = c.Runes[0]
+		}
+	}
+	return f
+}
+
+func hex(c tcell.Color) int32 {
+	if c == tcell.ColorDefault {
+		return DefaultColor
+	}
+	return c.Hex()
+}
+
+// At returns the cell at the given position.
+//
+// Positions outside of the frame return an empty cell.
+func (f *Frame) At(x, y int) Cell {
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return Cell{Rune: ' ', Fg: DefaultColor, Bg: DefaultColor}
+	}
+	return f.Cells[y*f.Width+x]
+}
+
+// Line returns the text of the given line, with trailing spaces removed.
+func (f *Frame) Line(y int) string {
+	runes := make([]rune, f.Width)
+	for x := range runes {
+		runes[x] = f.At(x, y).Rune
+	}
+	return strings.TrimRight(string(runes), " ")
+}
+
+// Lines returns the text of each line of the frame.
+func (f *Frame) Lines() []string {
+	lines := make([]string, f.Height)
+	for y := range lines {
+		lines[y] = f.Line(y)
+	}
+	return lines
+}
+
+// String returns the text of the frame, one line per row.
+func (f *Frame) String() string {
+	return strings.Join(f.Lines(), "\n")
+}
+
+// Find returns the position of the first occurrence of the text in the
+// frame, searching line by line. If the text isn't found, this returns false.
+func (f *Frame) Find(text string) (int, int, bool) {
+	for y := 0; y < f.Height; y++ {
+		if i := strings.Index(f.Line(y), text); i >= 0 {
+			return len([]rune(f.Line(y)[:i])), y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// Contains returns true if the text appears on any line of the frame.
+func (f *Frame) Contains(text string) bool {
+	_, _, ok := f.Find(text)
+	return ok
+}
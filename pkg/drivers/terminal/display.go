@@ -2,6 +2,8 @@ package terminal
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell"
 	"github.com/tvarney/grogue/pkg/game"
@@ -15,11 +17,8 @@ var (
 	optionStyle   = tcell.StyleDefault
 	selectedStyle = tcell.StyleDefault.Underline(true).Foreground(tcell.ColorTeal)
 
-	grassStyles = []tcell.Style{
-		tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(color.DarkGreen.Value()))),
-		tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(color.Green.Value()))),
-	}
-	emptyStyle = tcell.StyleDefault.
+	grassColors = []color.Enum{color.DarkGreen, color.Green}
+	emptyStyle  = tcell.StyleDefault.
 			Foreground(tcell.NewHexColor(0x001111)).
 			Background(tcell.NewHexColor(0x002222))
 	playerStyle = tcell.StyleDefault.Bold(true)
@@ -28,13 +27,29 @@ var (
 const (
 	cursor      = "* "
 	eraseCursor = "  "
+
+	// messageLines is the number of messages shown below the map.
+	messageLines = 3
 )
 
 func (d *Driver) Clear() {
 	d.screen.Clear()
 }
 
+// Render draws the application, clearing the screen first if a full render
+// is requested.
+func (d *Driver) Render(app *game.Application, req game.RenderRequest) {
+	if req == game.RenderFull {
+		d.Clear()
+	}
+	d.Draw(app)
+}
+
+// Draw draws the current menu, or the game if no menu is shown.
 func (d *Driver) Draw(app *game.Application) {
+	d.useGlyphs(app.Settings.GlyphSet, app.Game)
+	d.useColors(app.Settings.ColorMode)
+	d.palette = &app.Palette
 	menu := app.GetMenu()
 	if menu != nil {
 		d.drawMenu(app, menu)
@@ -42,55 +57,225 @@ func (d *Driver) Draw(app *game.Application) {
 	}
 
 	if !app.InGame {
-		app.Quit()
 		return
 	}
 	d.drawGame(app)
 }
 
 func (d *Driver) drawGame(app *game.Application) {
-	game := app.Game
-	currChunk := game.ActiveChunks[(game.Player.Chunk.X+1)+(game.Player.Chunk.Y+1)*3]
+	g := app.Game
+	focus := g.Focus()
+	l := d.layout()
+	v := newViewport(g, focus, 0, 0, l.mapWidth, l.mapHeight)
+	depth := game.ViewDepth
+	view := "Top"
+	if g.MapView == game.MapSide {
+		v = newSideViewport(g, focus, 0, 0, l.mapWidth, l.mapHeight)
+		depth = 0
+		view = "Side"
+	}
 
-	for y := uint16(0); y < chunk.Length; y++ {
-		if int(y) >= d.height {
-			break
+	for sy := v.sy; sy < v.sy+v.height; sy++ {
+		for sx := v.sx; sx < v.sx+v.width; sx++ {
+			d.drawTile(g, v.world(sx, sy), sx, sy, depth, v.side)
 		}
-		for x := uint16(0); x < chunk.Width; x++ {
-			if int(x) >= d.width {
-				break
+	}
+	for _, cr := range g.Creatures {
+		sx, sy, ok := v.screen(cr.Pos)
+		if !ok || !g.IsVisible(cr.Pos) {
+			continue
+		}
+		sp := g.SpeciesOf(cr)
+		d.setContent(sx, sy, sp.Glyph, mapStyle(d.colorStyle(sp.Color), lit(g.LightLevel(cr.Pos))))
+	}
+	if sx, sy, ok := v.screen(g.Player); ok {
+		d.setContent(sx, sy, d.player.Rune(0, 0, 0, 0, nil, nil), playerStyle)
+	}
+
+	currTile := g.Tile(g.Player)
+	d.clearLine(l.statusY)
+	d.drawString(0, l.statusY, fmt.Sprintf("Z: %2d | %s | Random: 0x%08X", focus.Z, view, currTile.Random), tcell.StyleDefault)
+	d.clearLine(l.statusY + 1)
+	if g.Cursor.Mode != game.CursorNone {
+		d.drawCursor(g, &v, l)
+	} else {
+		d.drawString(0, l.statusY+1, fmt.Sprintf("Tile: %s", currTile.Describe(g.Blocks, g.Floors, g.Materials)), tcell.StyleDefault)
+	}
+	d.drawMessages(g, l.statusY+statusLines)
+	if l.sidebar && g.Cursor.Mode != game.CursorLook {
+		d.drawSidebar(g, l.sidebarX, 0, l.mapHeight)
+	}
+
+	d.screen.Show()
+}
+
+// drawMessages draws the most recent messages starting at the given line.
+func (d *Driver) drawMessages(g *game.Game, y int) {
+	for i := 0; i < messageLines; i++ {
+		d.clearLine(y + i)
+	}
+	for i, m := range g.Messages.Last(messageLines) {
+		d.drawString(0, y+i, m.String(), d.colorStyle(m.Severity.Color()))
+	}
+}
+
+// drawCursor draws the map cursor and a description of the tile under it.
+//
+// In look mode, this also draws the examine panel to the right of the map.
+func (d *Driver) drawCursor(g *game.Game, v *viewport, l layout) {
+	pos := g.Cursor.Pos
+	if sx, sy, ok := v.screen(pos); ok {
+		r, _, s, _ := d.screen.GetContent(sx, sy)
+		d.setContent(sx, sy, r, s.Reverse(true))
+	}
+
+	e := g.Examine(pos)
+	desc := e.Description
+	if len(e.Entities) > 0 {
+		desc += "; " + strings.Join(e.Entities, ", ")
+	}
+	if len(e.Items) > 0 {
+		desc += "; " + strings.Join(e.Items, ", ")
+	}
+	if e.Known && !e.Visible {
+		desc += " (remembered)"
+	}
+
+	switch g.Cursor.Mode {
+	case game.CursorTravel:
+		d.drawString(0, l.statusY+1, fmt.Sprintf("Travel to: %s", desc), tcell.StyleDefault)
+	case game.CursorLook:
+		d.drawString(0, l.statusY+1, fmt.Sprintf("Look: %s", desc), tcell.StyleDefault)
+		if l.sidebar {
+			d.drawExamine(e, l.sidebarX, 0, l.mapHeight)
+		}
+	}
+}
+
+// drawExamine draws the details of an examined tile at the given position.
+//
+// The panel is cleared for the given number of lines.
+func (d *Driver) drawExamine(e game.Examination, x, y, height int) {
+	lines := []string{
+		fmt.Sprintf("Position: %d,%d,%d", e.Pos.X, e.Pos.Y, e.Pos.Z),
+		fmt.Sprintf("Chunk:    %d,%d", e.Pos.Chunk.X, e.Pos.Chunk.Y),
+	}
+	switch {
+	case e.Visible:
+		lines = append(lines, "Visible")
+	case e.Known:
+		lines = append(lines, "Remembered")
+	default:
+		lines = append(lines, "Unknown")
+	}
+	if len(e.Entities) > 0 {
+		lines = append(lines, "Here: "+strings.Join(e.Entities, ", "))
+	}
+	if len(e.Items) > 0 {
+		lines = append(lines, "Items: "+strings.Join(e.Items, ", "))
+	}
+	for _, p := range e.Parts {
+		lines = append(lines,
+			"",
+			fmt.Sprintf("%s: %s", strings.ToUpper(p.Kind[:1])+p.Kind[1:], p.Name),
+			fmt.Sprintf("  Material: %s (%s)", p.State.Name, p.Material.Type),
+			fmt.Sprintf("  Color:    %s", p.State.Color.Name()),
+		)
+		if p.Kind == "liquid" {
+			lines = append(lines, fmt.Sprintf("  Depth:    %d/7", e.Liquid))
+		}
+		if p.State.Light > 0 {
+			lines = append(lines, fmt.Sprintf("  Light:    %d", p.State.Light))
+		}
+	}
+
+	for i := 0; i < height; i++ {
+		d.clearRegion(x, y+i, d.width-x)
+		if i < len(lines) {
+			d.drawString(x, y+i, lines[i], tcell.StyleDefault)
+		}
+	}
+}
+
+// drawTile draws the tile at the given coordinates at the screen position
+// (sx,sy).
+//
+// Tiles which are currently visible are drawn shaded by their light level,
+// tiles which have been seen before are drawn as they were remembered in faded
+// colors, and tiles which have never been seen are drawn with the unknown
+// displayer. Positions outside of the loaded world are left blank.
+//
+// If the tile is empty, up to depth levels below it are looked down through
+// to find something to draw, which is shaded by how far down it is. If side
+// is true, the tile is drawn in the side view.
+func (d *Driver) drawTile(g *game.Game, pos game.Coords, sx, sy, depth int, side bool) {
+	if pos.Z < 0 || pos.Z >= chunk.Height || g.Chunk(pos.Chunk) == nil {
+		d.setContent(sx, sy, ' ', tcell.StyleDefault)
+		return
+	}
+
+	var shade func(uint32) uint32
+	for i := 0; i <= depth && pos.Z-i >= 0; i++ {
+		at := pos.Offset(0, 0, -i)
+		t, visible := g.Tile(at), g.IsVisible(at)
+		f := lit(g.LightLevel(at))
+		if !visible {
+			t, f = g.Recall(at), faded
+		}
+		if t == nil {
+			if i == 0 {
+				d.setContent(sx, sy, d.unknown.Rune(pos.Chunk.X, pos.Chunk.Y, uint16(pos.X), uint16(pos.Y), nil, nil), d.colorStyle(color.DarkGray))
+				return
 			}
+			break
+		}
+		if shade == nil {
+			shade = f
+		}
 
-			d.drawTile(game, currChunk, game.Player.Chunk.X, game.Player.Chunk.Y, x, y)
+		r, s, ok := d.tileContent(g, neighborhood{g: g, pos: at, side: side}, t, i > 0)
+		if !ok {
+			continue
+		}
+		if visible {
+			if items := g.Chunk(at.Chunk).ItemsAt(at.X, at.Y, at.Z); items != nil {
+				it := &items.Items[items.Len()-1]
+				r = d.items[it.Kind].Rune(at.Chunk.X, at.Chunk.Y, uint16(at.X), uint16(at.Y), nil, nil)
+				s = s.Foreground(d.color(it.Color(g.Materials)))
+			}
 		}
+		d.setContent(sx, sy, r, mapStyle(mapStyle(s, f), deeper(i)))
+		return
 	}
-	d.screen.SetContent(game.Player.X, game.Player.Y, d.player.Rune(0, 0, 0, 0, nil), nil, playerStyle)
-	currTile := currChunk.Get(game.Player.X, game.Player.Y, game.Player.Z)
-	d.drawString(0, chunk.Length, fmt.Sprintf("Z: %2d | Random: 0x%08X", game.Player.Z, currTile.Random), tcell.StyleDefault)
-	d.clearLine(chunk.Length + 1)
-	d.drawString(0, chunk.Length+1, fmt.Sprintf("Tile: %s", currTile.Describe(game.Blocks, game.Floors, game.Materials)), tcell.StyleDefault)
 
-	d.screen.Show()
+	// Nothing was found; looking into the void
+	d.setContent(sx, sy, '.', mapStyle(emptyStyle, shade))
 }
 
-func (d *Driver) drawTile(game *game.Game, c *chunk.Chunk, cx, cy int, x, y uint16) {
-	t := c.Get(int(x), int(y), game.Player.Z)
+// tileContent returns the rune and style used to display the tile t at the
+// position of the neighborhood, or false if there is nothing in the tile to
+// display.
+//
+// If below is true, the tile is being looked down on from a higher level, and
+// blocks are drawn as the rough floor on top of them.
+func (d *Driver) tileContent(g *game.Game, n neighborhood, t *tile.State, below bool) (rune, tcell.Style, bool) {
+	cx, cy := n.pos.Chunk.X, n.pos.Chunk.Y
+	x, y := uint16(n.pos.X), uint16(n.pos.Y)
 
 	// Tile contains liquid
 	if t.Liquid > 0 {
-		mat := game.Materials[t.LiquidMat]
-		s := tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(mat.Liquid.Color.Value())))
-		d.screen.SetContent(int(x), int(y), d.liquid.Rune(cx, cy, x, y, t), nil, s)
-		return
+		mat := g.Materials[t.LiquidMat]
+		return d.liquid.Rune(cx, cy, x, y, t, n), d.colorStyle(mat.Liquid.Color), true
 	}
 
 	// Tile contains a block
 	block := t.Block.Definition
 	if block != tile.BlockEmpty {
-		mat := game.Materials[t.Block.Material]
-		s := tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(mat.Solid.Color.Value())))
-		d.screen.SetContent(int(x), int(y), d.blocks[block].Rune(cx, cy, x, y, t), nil, s)
-		return
+		mat := g.Materials[t.Block.Material]
+		if below {
+			return d.floors[tile.FloorRough].Rune(cx, cy, x, y, t, n), d.colorStyle(mat.Solid.Color), true
+		}
+		return d.blocks[block].Rune(cx, cy, x, y, t, n), d.colorStyle(mat.Solid.Color), true
 	}
 
 	// Tile has a floor
@@ -98,71 +283,117 @@ func (d *Driver) drawTile(game *game.Game, c *chunk.Chunk, cx, cy int, x, y uint
 	if floor != tile.FloorEmpty {
 		if t.Flags&tile.HasGrass != 0 {
 			r := (t.Random >> 16) | (t.Random << 16)
-			gc := grassStyles[int(r)%len(grassStyles)]
-			d.screen.SetContent(int(x), int(y), d.grass.Rune(0, 0, x, y, t), nil, gc)
-			return
+			gc := grassColors[int(r)%len(grassColors)]
+			return d.grass.Rune(0, 0, x, y, t, n), d.colorStyle(gc), true
 		}
-		mat := game.Materials[t.Floor.Material]
-		s := tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(mat.Solid.Color.Value())))
-		d.screen.SetContent(int(x), int(y), d.floors[floor].Rune(cx, cy, x, y, t), nil, s)
-		return
+		mat := g.Materials[t.Floor.Material]
+		return d.floors[floor].Rune(cx, cy, x, y, t, n), d.colorStyle(mat.Solid.Color), true
 	}
 
-	if game.Player.Z == 0 {
-		d.screen.SetContent(int(x), int(y), '.', nil, emptyStyle)
-		return
-	}
+	return 0, tcell.StyleDefault, false
+}
 
-	below := c.Get(int(x), int(y), game.Player.Z-1)
-	// Below is liquid
-	if below.Liquid > 0 {
-		mat := game.Materials[below.LiquidMat]
-		s := tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(mat.Liquid.Color.Value())))
-		d.screen.SetContent(int(x), int(y), d.liquid.Rune(cx, cy, x, y, below), nil, s)
+func (d *Driver) drawMenu(app *game.Application, menu game.Menu) {
+	d.clearLine(0)
+	if ov, ok := menu.(game.MapOverview); ok {
+		d.drawOverview(app, menu, ov)
 		return
 	}
-
-	// Below is solid
-	block = below.Block.Definition
-	if block != tile.BlockEmpty {
-		mat := game.Materials[t.Floor.Material]
-		s := tcell.StyleDefault.Foreground(tcell.NewHexColor(int32(mat.Solid.Color.Value())))
-		d.screen.SetContent(int(x), int(y), d.floors[tile.FloorRough].Rune(cx, cy, x, y, below), nil, s)
+	d.drawStringCentered(0, menu.GetTitle(), titleStyle)
+	if entry, ok := menu.(game.TextEntry); ok {
+		d.drawTextEntry(menu, entry)
 		return
 	}
 
-	// Below is 'empty'
-	d.screen.SetContent(int(x), int(y), '.', nil, emptyStyle)
-}
-
-func (d *Driver) drawMenu(app *game.Application, menu game.Menu) {
-	d.drawStringCentered(0, menu.GetTitle(), titleStyle)
-
 	opts := menu.GetOptions()
 	maxlen := 0
 	for _, o := range opts {
-		if len(o) > maxlen {
-			maxlen = len(o)
+		if n := utf8.RuneCountInString(o); n > maxlen {
+			maxlen = n
 		}
 	}
-	opt_x := (d.width - (maxlen + len(cursor))) / 2
+	opt_x := (d.width - (maxlen + utf8.RuneCountInString(cursor))) / 2
+	if opt_x < 0 {
+		opt_x = 0
+	}
 
+	// Scroll the options if there are too many to fit on the screen, keeping
+	// the selected option in view.
 	selected := menu.GetOption()
-	for i, option := range menu.GetOptions() {
+	rows := d.height - 2
+	first := 0
+	if len(opts) > rows {
+		first = selected - rows/2
+		if first > len(opts)-rows {
+			first = len(opts) - rows
+		}
+		if first < 0 {
+			first = 0
+		}
+	}
+
+	colored, _ := menu.(game.ColoredMenu)
+	for row := 0; row < rows; row++ {
+		d.clearLine(2 + row)
+		i := first + row
+		if i >= len(opts) {
+			continue
+		}
+
+		style := optionStyle
+		if colored != nil {
+			if c, ok := colored.GetOptionColor(i); ok {
+				style = d.colorStyle(c)
+			}
+		}
 		if i == selected {
-			d.drawString(opt_x, 2+i, cursor+option, selectedStyle)
+			d.drawString(opt_x, 2+row, cursor+opts[i], selectedStyle)
 		} else {
-			d.drawString(opt_x, 2+i, eraseCursor+option, optionStyle)
+			d.drawString(opt_x, 2+row, eraseCursor+opts[i], style)
 		}
 	}
 
 	d.screen.Show()
 }
 
+// drawTextEntry draws the prompt and text of a text entry menu, with the
+// cursor shown as a reversed cell, and the reason the text was rejected
+// below it.
+func (d *Driver) drawTextEntry(menu game.Menu, entry game.TextEntry) {
+	for y := 1; y < d.height; y++ {
+		d.clearLine(y)
+	}
+	// Layout is by rune rather than by byte, as names may hold any printable
+	// character.
+	line := menu.GetOptions()[0]
+	width := utf8.RuneCountInString(line)
+	x := (d.width - width) / 2
+	if x < 0 {
+		x = 0
+	}
+	d.drawString(x, 2, line, optionStyle)
+
+	text := []rune(entry.GetText())
+	cx := x + width - len(text) + entry.GetCursor()
+	r := ' '
+	if entry.GetCursor() < len(text) {
+		r = text[entry.GetCursor()]
+	}
+	d.setContent(cx, 2, r, optionStyle.Reverse(true))
+
+	if err := entry.GetError(); err != "" {
+		d.drawStringCentered(4, err, d.colorStyle(color.Red))
+	}
+	d.screen.Show()
+}
+
+// drawString draws the string starting at the screen position (x,y), one
+// cell per rune, clipped to the screen.
 func (d *Driver) drawString(x, y int, str string, style tcell.Style) {
-	if y >= d.height {
+	if y >= d.height || x >= d.width {
 		return
 	}
+	runes := []rune(str)
 
 	// Figure out _where_ in the string to start
 	start := 0
@@ -170,24 +401,24 @@ func (d *Driver) drawString(x, y int, str string, style tcell.Style) {
 		start = -x
 	}
 	// If that's past the end, nothing to draw
-	if start > len(str) {
+	if start > len(runes) {
 		return
 	}
 
 	// Calculate how much of the string to draw; if x is negative, this will
 	// 'draw' more than the screen width by skipping the start bits.
 	n := d.width - x
-	if len(str) < n {
-		n = len(str)
+	if len(runes) < n {
+		n = len(runes)
 	}
 
-	for i, r := range str[start:n] {
-		d.screen.SetContent(i+x, y, r, nil, style)
+	for i := start; i < n; i++ {
+		d.setContent(i+x, y, runes[i], style)
 	}
 }
 
 func (d *Driver) drawStringCentered(y int, str string, style tcell.Style) {
-	d.drawString((d.width-len(str))/2, y, str, style)
+	d.drawString((d.width-utf8.RuneCountInString(str))/2, y, str, style)
 }
 
 func (d *Driver) clearLine(y int) {
@@ -195,6 +426,16 @@ func (d *Driver) clearLine(y int) {
 		return
 	}
 	for x := 0; x < d.width; x++ {
-		d.screen.SetContent(x, y, ' ', nil, tcell.StyleDefault)
+		d.setContent(x, y, ' ', tcell.StyleDefault)
+	}
+}
+
+// clearRegion clears n cells of the given line, starting at x.
+func (d *Driver) clearRegion(x, y, n int) {
+	if y >= d.height {
+		return
+	}
+	for i := 0; i < n && x+i < d.width; i++ {
+		d.setContent(x+i, y, ' ', tcell.StyleDefault)
 	}
 }
@@ -5,35 +5,89 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell"
 	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/color"
 )
 
 // Driver is the terminal driver struct.
 type Driver struct {
-	blocks []Displayer
-	floors []Displayer
-	grass  Displayer
-	liquid Displayer
-	player Displayer
+	blocks  []Displayer
+	floors  []Displayer
+	grass   Displayer
+	liquid  Displayer
+	items   []Displayer
+	player  Displayer
+	unknown Displayer
+
+	// glyphSets holds the glyph sets which may be drawn with, by name, and
+	// glyphs is the name of the set the displayers were taken from.
+	glyphSets map[string]GlyphSet
+	glyphs    string
+
+	// colors is the number of colors the driver draws with, chosen for
+	// colorMode. If forceColors is set, it is used instead of the color mode
+	// of the settings.
+	colors      int
+	colorMode   string
+	forceColors string
+
+	// palette holds the colors of the application being drawn, and is set
+	// each time it is drawn.
+	palette *color.Palette
 
 	logfile string
 	logfp   io.WriteCloser
 	screen  tcell.Screen
+	events  chan tcell.Event
 	width   int
 	height  int
 }
 
+// autoDelay is how long the driver waits for input between the steps of an
+// automatic movement.
+const autoDelay = 50 * time.Millisecond
+
 // New creates a new Driver with a new game instance.
 func New() *Driver {
-	return &Driver{
-		blocks: DefaultBlocks(),
-		floors: DefaultFloors(),
-		grass:  Random([]rune{'.', '.', '.', ',', ';'}),
-		liquid: LiquidNumber{},
-		player: Simple('☺'),
+	d := &Driver{glyphSets: map[string]GlyphSet{}}
+	for _, set := range DefaultGlyphSets() {
+		d.glyphSets[set.Name] = set
+	}
+	return d
+}
+
+// SetColorMode sets the color mode the driver draws with, overriding the
+// color mode chosen in the settings. If mode is empty, the color mode of the
+// settings is used.
+func (d *Driver) SetColorMode(mode string) {
+	d.forceColors = mode
+}
+
+// useColors sets the number of colors the driver draws with for the named
+// color mode. The auto mode uses as many colors as the terminal supports.
+func (d *Driver) useColors(mode string) {
+	if d.forceColors != "" {
+		mode = d.forceColors
 	}
+	if mode == d.colorMode {
+		return
+	}
+	d.colorMode = mode
+	switch mode {
+	case game.ColorTrue:
+		d.colors = trueColors
+	case game.Color256:
+		d.colors = 256
+	case game.Color16:
+		d.colors = 16
+	default:
+		d.colors = d.screen.Colors()
+	}
+	log.Printf("terminal.Driver::useColors(): Using %d colors for color mode %q", d.colors, mode)
 }
 
 func (d *Driver) openlog(filename string) error {
@@ -66,15 +120,11 @@ func (d *Driver) openlog(filename string) error {
 
 // SetLog sets the logging behavior of the driver.
 //
-// As the driver makes use of the standard log package, this will set the
-// filename to redirect the log package to. If filename is the empty string,
-// this will result in a NopCloser
+// As the driver makes use of the standard log package, this will redirect
+// the log package to the given file immediately, so nothing is written to the
+// terminal. If filename is the empty string, log output is discarded.
 func (d *Driver) SetLog(filename string) error {
-	if d.screen != nil {
-		return d.openlog(filename)
-	}
-	d.logfile = filename
-	return nil
+	return d.openlog(filename)
 }
 
 // Init initializes the terminal driver.
@@ -87,21 +137,50 @@ func (d *Driver) Init() error {
 	if err != nil {
 		return err
 	}
+	return d.InitScreen(s)
+}
+
+// InitScreen initializes the terminal driver with the given screen instead of
+// the real terminal.
+//
+// This allows the driver to draw to a tcell.SimulationScreen.
+func (d *Driver) InitScreen(s tcell.Screen) error {
+	if d.screen != nil {
+		return nil
+	}
 	if err := s.Init(); err != nil {
 		return err
 	}
 
-	// Just discard the error; ideally we would report this, but a failure to
-	// open the log file shouldn't break our init function either. That makes
-	// it a good candidate to log...but we just failed to open the log, so it
-	// doesn't matter.
-	_ = d.openlog(d.logfile)
-
 	d.screen = s
+	d.events = make(chan tcell.Event, 16)
 	d.width, d.height = s.Size()
+	go d.pollEvents(s, d.events)
 	return nil
 }
 
+// SetSize sets the size of the area the driver draws to.
+//
+// This is only needed when the size of the screen is changed without a
+// resize event, such as when resizing a tcell.SimulationScreen.
+func (d *Driver) SetSize(width, height int) {
+	d.width, d.height = width, height
+}
+
+// pollEvents forwards events from the screen to the events channel.
+//
+// The channel is closed once the screen has been finalized.
+func (d *Driver) pollEvents(s tcell.Screen, events chan<- tcell.Event) {
+	for {
+		ev := s.PollEvent()
+		if ev == nil {
+			close(events)
+			return
+		}
+		events <- ev
+	}
+}
+
 // Finalize releases terminal driver resources.
 func (d *Driver) Finalize() {
 	if d.screen == nil {
@@ -109,15 +188,33 @@ func (d *Driver) Finalize() {
 	}
 	d.screen.Fini()
 	d.screen = nil
+	if d.logfp != nil {
+		log.SetOutput(ioutil.Discard)
+		d.logfp.Close()
+		d.logfp = nil
+	}
 }
 
 // PollAction gets the next action to update the game with.
+//
+// If the application is busy, this returns game.ActionContinue if no input is
+// received within a short delay, or game.ActionInterrupt if a key is pressed.
 func (d *Driver) PollAction(app *game.Application) game.Action {
 	var action game.Action
 	for action == game.ActionNone {
-		ev := d.screen.PollEvent()
-		if ev == nil {
-			// ev may only be nil if the terminal is not initialized, so
+		var ev tcell.Event
+		var ok bool
+		if app.Busy() {
+			select {
+			case ev, ok = <-d.events:
+			case <-time.After(autoDelay):
+				return game.ActionContinue
+			}
+		} else {
+			ev, ok = <-d.events
+		}
+		if !ok {
+			// The channel is only closed if the terminal is finalized, so
 			// handle this as if the 'window' was closed.
 			return game.ActionQuit
 		}
@@ -128,71 +225,125 @@ func (d *Driver) PollAction(app *game.Application) game.Action {
 			d.screen.Sync()
 			d.Draw(app)
 		case *tcell.EventKey:
-			menu := app.GetMenu()
-			if menu != nil {
-				action = d.HandleKeyEventMenu(app, e)
-			} else {
-				action = d.HandleKeyEventGame(app, e)
+			switch {
+			case app.GetMenu() != nil:
+				switch m := app.GetMenu().(type) {
+				case game.TextEntry:
+					action = d.HandleKeyEventText(app, m, e)
+				case game.KeyCapture:
+					action = d.HandleKeyEventCapture(app, m, e)
+				case game.MapOverview:
+					action = d.HandleKeyEvent(app, game.KeyContextMap, e)
+				default:
+					action = d.HandleKeyEvent(app, game.KeyContextMenu, e)
+				}
+			case app.Busy():
+				if e.Key() == tcell.KeyCtrlC {
+					return game.ActionQuit
+				}
+				action = game.ActionInterrupt
+			case app.Game.Cursor.Mode != game.CursorNone:
+				action = d.HandleKeyEvent(app, game.KeyContextLook, e)
+			default:
+				action = d.HandleKeyEvent(app, game.KeyContextGame, e)
 			}
 		default:
-			app.Quit()
 			log.Printf("Unknown event <%T>: %v", ev, ev)
+			return game.ActionQuit
 		}
 	}
 
 	return action
 }
 
-func (d *Driver) HandleKeyEventGame(app *game.Application, event *tcell.EventKey) game.Action {
-	switch event.Key() {
-	case tcell.KeyRune:
-		switch event.Rune() {
-		case '>':
-			return game.ActionMoveUp
-		case '<':
-			return game.ActionMoveDown
-		case 'j':
-			return game.ActionMoveSouth
-		case 'k':
-			return game.ActionMoveNorth
-		case 'h':
-			return game.ActionMoveWest
-		case 'l':
-			return game.ActionMoveEast
-		case '.':
-			return game.ActionWait
+// keyName returns the name of the key of the event, as used by game.Keymap.
+//
+// Printable keys are named by their character, with an "Alt+" prefix if alt
+// was held. Other keys use the tcell key names, with modifiers joined by '+'
+// (e.g. "Up", "Shift+Left", "Ctrl+P").
+func keyName(event *tcell.EventKey) string {
+	mod := event.Modifiers()
+	if event.Key() == tcell.KeyRune {
+		if mod&tcell.ModAlt != 0 {
+			return "Alt+" + string(event.Rune())
 		}
-	case tcell.KeyLeft:
-		return game.ActionMoveWest
-	case tcell.KeyRight:
-		return game.ActionMoveEast
-	case tcell.KeyUp:
-		return game.ActionMoveNorth
-	case tcell.KeyDown:
-		return game.ActionMoveSouth
+		return string(event.Rune())
+	}
+
+	name, ok := tcell.KeyNames[event.Key()]
+	if !ok {
+		return ""
+	}
+	switch {
+	case event.Key() == tcell.KeyBackspace2:
+		name = "Backspace"
+	case strings.HasPrefix(name, "Ctrl-"):
+		name = "Ctrl+" + name[5:]
+		mod &^= tcell.ModCtrl
+	}
+	if mod&tcell.ModCtrl != 0 {
+		name = "Ctrl+" + name
+	}
+	if mod&tcell.ModAlt != 0 {
+		name = "Alt+" + name
+	}
+	if mod&tcell.ModShift != 0 {
+		name = "Shift+" + name
+	}
+	return name
+}
+
+// HandleKeyEvent translates a key event to an action using the key bindings
+// of the given context.
+func (d *Driver) HandleKeyEvent(app *game.Application, context string, event *tcell.EventKey) game.Action {
+	return app.Keymap.Lookup(context, keyName(event))
+}
+
+// HandleKeyEventCapture handles key events for menus which wait for a key to
+// be pressed, passing the name of the key to the menu.
+//
+// Escape cancels the capture, so it can't be bound this way.
+func (d *Driver) HandleKeyEventCapture(app *game.Application, capture game.KeyCapture, event *tcell.EventKey) game.Action {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		return game.ActionMenuClose
 	case tcell.KeyCtrlC:
 		return game.ActionQuit
 	}
-	return game.ActionNone
+	name := keyName(event)
+	if name == "" {
+		return game.ActionNone
+	}
+	capture.SetKey(name)
+	return game.ActionMenuSelect
 }
 
-func (d *Driver) HandleKeyEventMenu(app *game.Application, event *tcell.EventKey) game.Action {
+// HandleKeyEventText handles key events for menus which accept typed text.
+//
+// Typed characters are inserted into the text directly, redrawing the menu,
+// while editing keys are translated to menu actions.
+func (d *Driver) HandleKeyEventText(app *game.Application, entry game.TextEntry, event *tcell.EventKey) game.Action {
 	switch event.Key() {
 	case tcell.KeyRune:
-		switch event.Rune() {
-		case 'j':
-			return game.ActionMenuDown
-		case 'k':
-			return game.ActionMenuUp
+		if entry.Insert(event.Rune()) {
+			d.Draw(app)
 		}
-	case tcell.KeyEscape:
-		return game.ActionMenuClose
-	case tcell.KeyDown:
-		return game.ActionMenuDown
-	case tcell.KeyUp:
-		return game.ActionMenuUp
+	case tcell.KeyLeft:
+		return game.ActionMenuLeft
+	case tcell.KeyRight:
+		return game.ActionMenuRight
+	case tcell.KeyHome, tcell.KeyCtrlA:
+		return game.ActionMenuHome
+	case tcell.KeyEnd, tcell.KeyCtrlE:
+		return game.ActionMenuEnd
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		return game.ActionMenuBackspace
+	case tcell.KeyDelete:
+		return game.ActionMenuDelete
 	case tcell.KeyEnter:
 		return game.ActionMenuSelect
+	case tcell.KeyEscape:
+		return game.ActionMenuClose
 	case tcell.KeyCtrlC:
 		return game.ActionQuit
 	}
@@ -0,0 +1,267 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/tvarney/grogue/pkg/cerr"
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+const (
+	ErrUnknownDisplayer = cerr.Error("unknown displayer type")
+	ErrInvalidRunes     = cerr.Error("invalid runes for displayer")
+)
+
+// The keys of glyph set tiles which aren't tile definitions.
+const (
+	GlyphGrass   = "grass"
+	GlyphLiquid  = "liquid"
+	GlyphPlayer  = "player"
+	GlyphUnknown = "unknown"
+)
+
+// missingGlyph is shown for tiles and items which no glyph set has a glyph
+// for.
+const missingGlyph = Simple('?')
+
+// GlyphSpec defines a displayer of a glyph set.
+//
+// Type names the kind of displayer, as registered in displayerTypes, and
+// Runes holds the characters it draws with.
+type GlyphSpec struct {
+	Type  string `json:"type"`
+	Runes string `json:"runes"`
+}
+
+// GlyphSet is a named set of glyphs the map is drawn with.
+//
+// Tiles are keyed by the string ID of their definition (e.g.
+// "block-wall-rough"), or one of the Glyph* keys, and items are keyed by the
+// name of their kind. Glyphs missing from the set are taken from the
+// fallback set, and then from the ASCII set.
+type GlyphSet struct {
+	Name     string               `json:"name"`
+	Fallback string               `json:"fallback"`
+	Tiles    map[string]GlyphSpec `json:"tiles"`
+	Items    map[string]GlyphSpec `json:"items"`
+}
+
+// displayerTypes creates displayers from the runes of a GlyphSpec, keyed by
+// the name of the displayer type.
+var displayerTypes = map[string]func(runes []rune) (Displayer, error){
+	"simple": func(runes []rune) (Displayer, error) {
+		if len(runes) != 1 {
+			return nil, ErrInvalidRunes
+		}
+		return Simple(runes[0]), nil
+	},
+	"random": func(runes []rune) (Displayer, error) {
+		if len(runes) == 0 {
+			return nil, ErrInvalidRunes
+		}
+		return Random(runes), nil
+	},
+	"liquid": func(runes []rune) (Displayer, error) {
+		return LiquidNumber{}, nil
+	},
+	"wall": func(runes []rune) (Displayer, error) {
+		var w Wall
+		if len(runes) != len(w) {
+			return nil, ErrInvalidRunes
+		}
+		copy(w[:], runes)
+		return w, nil
+	},
+}
+
+// Displayer returns a new displayer as defined by the spec.
+func (s GlyphSpec) Displayer() (Displayer, error) {
+	create, ok := displayerTypes[s.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownDisplayer, s.Type)
+	}
+	d, err := create([]rune(s.Runes))
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %q", err, s.Type, s.Runes)
+	}
+	return d, nil
+}
+
+func simple(r rune) GlyphSpec {
+	return GlyphSpec{Type: "simple", Runes: string(r)}
+}
+
+func random(runes string) GlyphSpec {
+	return GlyphSpec{Type: "random", Runes: runes}
+}
+
+// wall returns the spec of a Wall displayer; runes holds the rune of each
+// combination of joined directions, in the order of the Wall indices.
+func wall(runes string) GlyphSpec {
+	return GlyphSpec{Type: "wall", Runes: runes}
+}
+
+// DefaultGlyphSets returns the built-in glyph sets; a set using box and shade
+// characters, and a set using only ASCII characters.
+func DefaultGlyphSets() []GlyphSet {
+	return []GlyphSet{
+		{
+			Name:     game.GlyphsUnicode,
+			Fallback: game.GlyphsASCII,
+			Tiles: map[string]GlyphSpec{
+				"block-empty":         simple(' '),
+				"block-stone":         simple('█'),
+				"block-soil":          simple('▓'),
+				"block-wall-rough":    wall("■┃━┗┃┃┏┣━┛━┻┓┫┳╋"),
+				"block-wall-smooth":   wall("○║═╚║║╔╠═╝═╩╗╣╦╬"),
+				"block-stairs-up":     simple('<'),
+				"block-stairs-down":   simple('>'),
+				"block-stairs-updown": simple('X'),
+				"block-ramp":          simple('▲'),
+				GlyphPlayer:           simple('☺'),
+			},
+			Items: map[string]GlyphSpec{
+				"gem":   simple('♦'),
+				"anvil": simple('Ω'),
+			},
+		},
+		{
+			Name: game.GlyphsASCII,
+			Tiles: map[string]GlyphSpec{
+				"block-empty":         simple(' '),
+				"block-stone":         simple('#'),
+				"block-soil":          simple('%'),
+				"block-wall-rough":    simple('#'),
+				"block-wall-smooth":   wall("O|-+||++-+-+++++"),
+				"block-stairs-up":     simple('<'),
+				"block-stairs-down":   simple('>'),
+				"block-stairs-updown": simple('X'),
+				"block-ramp":          simple('^'),
+				"floor-empty":         simple(' '),
+				"floor-stone":         simple('.'),
+				"floor-soil":          simple('.'),
+				"floor-rough":         simple('.'),
+				"floor-smooth":        simple('.'),
+				GlyphGrass:            random("...,;"),
+				GlyphLiquid:           {Type: "liquid"},
+				GlyphPlayer:           simple('@'),
+				GlyphUnknown:          simple(' '),
+			},
+			Items: map[string]GlyphSpec{
+				"rock":    simple('*'),
+				"boulder": simple('0'),
+				"bar":     simple('='),
+				"gem":     simple('$'),
+				"pick":    simple('('),
+				"axe":     simple('('),
+				"hammer":  simple('('),
+				"anvil":   simple('&'),
+				"helmet":  simple('['),
+				"cuirass": simple('['),
+				"corpse":  simple('%'),
+				"bone":    simple('~'),
+				"log":     simple('/'),
+			},
+		},
+	}
+}
+
+// LoadGlyphSet reads a glyph set from the JSON file at the given path.
+//
+// Every glyph of the set is checked, so that errors are reported when the
+// set is loaded rather than when it is drawn.
+func LoadGlyphSet(path string) (GlyphSet, error) {
+	var set GlyphSet
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return set, err
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return set, fmt.Errorf("invalid glyph set file %q: %w", path, err)
+	}
+	if set.Name == "" {
+		return set, fmt.Errorf("invalid glyph set file %q: missing name", path)
+	}
+	for _, specs := range []map[string]GlyphSpec{set.Tiles, set.Items} {
+		for key, spec := range specs {
+			if _, err := spec.Displayer(); err != nil {
+				return set, fmt.Errorf("invalid glyph set file %q: %s: %w", path, key, err)
+			}
+		}
+	}
+	return set, nil
+}
+
+// AddGlyphSet adds a glyph set the driver may draw with, replacing any set
+// with the same name.
+func (d *Driver) AddGlyphSet(set GlyphSet) {
+	d.glyphSets[set.Name] = set
+	if set.Name == d.glyphs {
+		d.glyphs = ""
+	}
+}
+
+// useGlyphs switches the displayers of the driver to the named glyph set,
+// looking up the glyphs of the game's tile definitions and item kinds.
+func (d *Driver) useGlyphs(name string, g *game.Game) {
+	if name == d.glyphs {
+		return
+	}
+	log.Printf("terminal.Driver::useGlyphs(): Using %q glyphs", name)
+	d.glyphs = name
+	d.blocks = d.tileGlyphs(name, g.Blocks)
+	d.floors = d.tileGlyphs(name, g.Floors)
+	d.grass = d.glyph(name, false, GlyphGrass)
+	d.liquid = d.glyph(name, false, GlyphLiquid)
+	d.player = d.glyph(name, false, GlyphPlayer)
+	d.unknown = d.glyph(name, false, GlyphUnknown)
+
+	kinds := item.Kinds()
+	d.items = make([]Displayer, len(kinds))
+	for i, k := range kinds {
+		d.items[i] = d.glyph(name, true, k.String())
+	}
+}
+
+// tileGlyphs returns the displayers of the tile definitions, in order.
+func (d *Driver) tileGlyphs(name string, defs []tile.Definition) []Displayer {
+	displayers := make([]Displayer, len(defs))
+	for i := range defs {
+		displayers[i] = d.glyph(name, false, defs[i].ID)
+	}
+	return displayers
+}
+
+// glyph returns the displayer for the tile or item key from the named glyph
+// set, or its fallbacks if the set doesn't have one.
+//
+// If no set has a valid glyph for the key, this returns missingGlyph.
+func (d *Driver) glyph(name string, isItem bool, key string) Displayer {
+	seen := map[string]bool{}
+	for name != "" && !seen[name] {
+		seen[name] = true
+		set := d.glyphSets[name]
+		specs := set.Tiles
+		if isItem {
+			specs = set.Items
+		}
+		if spec, ok := specs[key]; ok {
+			disp, err := spec.Displayer()
+			if err == nil {
+				return disp
+			}
+			log.Printf("terminal.Driver::glyph(): %s: %s: %v", name, key, err)
+		}
+		name = set.Fallback
+		if name == "" && !seen[game.GlyphsASCII] {
+			name = game.GlyphsASCII
+		}
+	}
+	log.Printf("terminal.Driver::glyph(): No glyph for %q", key)
+	return missingGlyph
+}
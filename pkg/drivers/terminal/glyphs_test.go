@@ -0,0 +1,147 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+func TestGlyphs(t *testing.T) {
+	t.Parallel()
+	blocks, floors := tile.DefaultDefinitions()
+	g := &game.Game{Blocks: blocks, Floors: floors}
+
+	t.Run("complete", func(t *testing.T) {
+		t.Parallel()
+		// Every tile and item has a glyph in each of the built-in sets
+		for _, set := range DefaultGlyphSets() {
+			d := New()
+			d.useGlyphs(set.Name, g)
+			for i, disp := range append(d.blocks, d.floors...) {
+				assert.NotEqual(t, missingGlyph, disp, "%s: tile %d", set.Name, i)
+			}
+			require.Len(t, d.items, len(item.Kinds()))
+			for i, disp := range d.items {
+				assert.NotEqual(t, missingGlyph, disp, "%s: item %d", set.Name, i)
+			}
+		}
+	})
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+		d := New()
+		d.AddGlyphSet(GlyphSet{
+			Name:     "walls",
+			Fallback: game.GlyphsUnicode,
+			Tiles:    map[string]GlyphSpec{"block-wall-rough": simple('%')},
+		})
+		d.useGlyphs("walls", g)
+		assert.Equal(t, Simple('%'), d.blocks[tile.BlockRoughWall])
+		assert.Equal(t, Simple('▲'), d.blocks[tile.BlockRamp])
+		assert.Equal(t, Simple('.'), d.floors[tile.FloorSoil])
+
+		d.useGlyphs("missing", g)
+		assert.Equal(t, Simple('^'), d.blocks[tile.BlockRamp])
+	})
+	t.Run("load", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "glyphs.json")
+		data := `{"name": "unicode", "tiles": {"block-soil": {"type": "random", "runes": "░▒"}}}`
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		set, err := LoadGlyphSet(path)
+		require.NoError(t, err)
+		assert.Equal(t, random("░▒"), set.Tiles["block-soil"])
+
+		for _, data := range []string{
+			`{"tiles": {}}`,
+			`{"name": "bad", "tiles": {"block-soil": {"type": "sparkle", "runes": "*"}}}`,
+			`{"name": "bad", "tiles": {"block-soil": {"type": "simple", "runes": "ab"}}}`,
+			`{"name": "bad", "items": {"rock": {"type": "random"}}}`,
+		} {
+			require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+			_, err := LoadGlyphSet(path)
+			assert.Error(t, err, data)
+		}
+	})
+}
+
+// testNeighborhood is a Neighborhood read from rows of a map, centered on
+// the middle of the map; '#' is a wall, '.' is open, and ' ' is unknown.
+type testNeighborhood []string
+
+func (n testNeighborhood) Neighbor(dx, dy int) *tile.State {
+	y, x := len(n)/2+dy, len(n[0])/2+dx
+	if y < 0 || y >= len(n) || x < 0 || x >= len(n[y]) {
+		return nil
+	}
+	switch n[y][x] {
+	case '#':
+		return &tile.State{Block: tile.Part{Definition: tile.BlockSmoothWall}}
+	case '.':
+		return &tile.State{Floor: tile.Part{Definition: tile.FloorSmooth}}
+	}
+	return nil
+}
+
+func TestWall(t *testing.T) {
+	t.Parallel()
+	spec := wall("○║═╚║║╔╠═╝═╩╗╣╦╬")
+	w, err := spec.Displayer()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		n        testNeighborhood
+		expected rune
+	}{
+		{"alone", testNeighborhood{".....", ".....", "..#..", ".....", "....."}, '○'},
+		{"corridor", testNeighborhood{"#####", "#####", "#####", ".....", "....."}, '═'},
+		{"corner", testNeighborhood{"..#..", "..#..", "..###", ".....", "....."}, '╚'},
+		{"junction", testNeighborhood{"#####", "#####", "#####", "..#..", "..#.."}, '╦'},
+		{"rock", testNeighborhood{"#####", "#####", "#####", "#####", "#####"}, '○'},
+		{"unknown", testNeighborhood{"     ", "     ", "  #  ", "  #  ", "  .  "}, '║'},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, string(tc.expected), string(w.Rune(0, 0, 0, 0, nil, tc.n)))
+		})
+	}
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, '○', w.Rune(0, 0, 0, 0, nil, nil))
+	})
+}
+
+func TestNeighborhood(t *testing.T) {
+	t.Parallel()
+	g := game.New(1).Game
+	g.WorldRadius = 0
+	g.ActiveChunks = []*chunk.Chunk{g.Generator.Flat(0, 0)}
+	// Remember a slice of the ground along y=5; soil below open air
+	for z := chunk.SurfaceLevel - 2; z <= chunk.SurfaceLevel+1; z++ {
+		for x := 3; x <= 7; x++ {
+			g.ActiveChunks[0].Remember(x, 5, z)
+		}
+	}
+
+	w, err := wall("○║═╚║║╔╠═╝═╩╗╣╦╬").Displayer()
+	require.NoError(t, err)
+	pos := game.Coords{X: 5, Y: 5, Z: chunk.SurfaceLevel - 1}
+	top := neighborhood{g: g, pos: pos}
+	side := neighborhood{g: g, pos: pos, side: true}
+	assert.Nil(t, top.Neighbor(0, -1))
+	assert.Equal(t, g.Recall(pos.Offset(0, 0, 1)), side.Neighbor(0, -1))
+	assert.Equal(t, g.Recall(pos.Offset(1, 0, -1)), side.Neighbor(1, 1))
+
+	// Looking down, the soil around the tile is buried; from the side, it
+	// lies along the surface
+	assert.Equal(t, "○", string(w.Rune(0, 0, 5, 5, nil, top)))
+	assert.Equal(t, "═", string(w.Rune(0, 0, 5, 5, nil, side)))
+}
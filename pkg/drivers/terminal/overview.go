@@ -0,0 +1,113 @@
+package terminal
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+const (
+	// minimapHeight is the number of lines of the sidebar used by the
+	// minimap, including its label.
+	minimapHeight = 10
+
+	// minimapScale is the number of tiles along each side of the square of
+	// columns shown by a single cell of the minimap.
+	minimapScale = 4
+
+	// reliefStep is how much darker a column is drawn for each level it is
+	// below the surface, and minRelief is the darkest it is drawn.
+	reliefStep = 0.05
+	minRelief  = 0.4
+)
+
+// relief returns the color transformation for a column summary with its
+// highest tile at the given z-level.
+func relief(z int) func(uint32) uint32 {
+	f := 1.0 - reliefStep*float64(chunk.SurfaceLevel-z)
+	if f < minRelief {
+		f = minRelief
+	} else if f > 1.0 {
+		f = 1.0
+	}
+	return func(v uint32) uint32 {
+		return color.Scale(v, f)
+	}
+}
+
+// drawColumns draws a summary of the world, centered on the world-space
+// position (cx,cy), into the screen region of the given size at (sx,sy).
+//
+// Each cell shows the summary of a zoom by zoom square of tile columns,
+// drawn as its color shaded by its height. Columns which haven't been seen
+// are left blank, and the cell holding the player is marked.
+func (d *Driver) drawColumns(g *game.Game, cx, cy, zoom, sx, sy, width, height int) {
+	// The top-left of the region, aligned to the zoom level so that the
+	// squares summarized don't shift as the center moves.
+	x0 := floorDiv(cx, zoom) - width/2
+	y0 := floorDiv(cy, zoom) - height/2
+	px, py := g.Player.Global()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			wx, wy := (x0+x)*zoom, (y0+y)*zoom
+			style := tcell.StyleDefault
+			if col, ok := g.Summarize(wx, wy, zoom); ok {
+				v := relief(col.Z)(d.palette.Value(col.Color))
+				style = style.Background(tcell.NewHexColor(int32(v)))
+			}
+			r := ' '
+			if px >= wx && px < wx+zoom && py >= wy && py < wy+zoom {
+				r = d.player.Rune(0, 0, 0, 0, nil, nil)
+				style = style.Foreground(tcell.ColorWhite).Bold(true)
+			}
+			d.setContent(sx+x, sy+y, r, style)
+		}
+	}
+}
+
+// drawMinimap draws the minimap, centered on the player, at the given
+// position.
+func (d *Driver) drawMinimap(g *game.Game, x, y, width int) {
+	d.drawString(x, y, "Map", tcell.StyleDefault.Bold(true))
+	px, py := g.Player.Global()
+	d.drawColumns(g, px, py, minimapScale, x, y+1, width, minimapHeight-1)
+}
+
+// drawOverview draws the full-screen overview map, with its title on the
+// first line and the keys used to control it on the last.
+func (d *Driver) drawOverview(app *game.Application, menu game.Menu, ov game.MapOverview) {
+	cx, cy := ov.GetCenter()
+	zoom := ov.GetZoom()
+	title := fmt.Sprintf("%s (1:%d)", menu.GetTitle(), zoom)
+	d.clearLine(0)
+	d.drawStringCentered(0, title, titleStyle)
+	d.drawColumns(app.Game, cx, cy, zoom, 0, 1, d.width, d.height-2)
+
+	keys := app.Keymap[game.KeyContextMap]
+	help := fmt.Sprintf("%s/%s: zoom  %s: close", firstKey(keys, game.ActionZoomIn),
+		firstKey(keys, game.ActionZoomOut), firstKey(keys, game.ActionMenuClose))
+	d.clearLine(d.height - 1)
+	d.drawString(0, d.height-1, help, tcell.StyleDefault)
+	d.screen.Show()
+}
+
+// firstKey returns the name of the first key bound to the action, or "?" if
+// it isn't bound.
+func firstKey(b game.Bindings, a game.Action) string {
+	if keys := b[a]; len(keys) > 0 {
+		return keys[0]
+	}
+	return "?"
+}
+
+// floorDiv returns a divided by b, rounded towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
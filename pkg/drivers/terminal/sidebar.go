@@ -0,0 +1,124 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+// barWidth is the width of the health and blood bars of the sidebar.
+const barWidth = 10
+
+// sidebarLine is a single line of the status sidebar.
+type sidebarLine struct {
+	text  string
+	style tcell.Style
+}
+
+// drawSidebar draws the status of the player's character at the given
+// position.
+//
+// The sidebar is cleared for the given number of lines. If there is room
+// below the status, the minimap is drawn at the bottom of the sidebar.
+func (d *Driver) drawSidebar(g *game.Game, x, y, height int) {
+	c := &g.Character
+	b := c.Body
+	plain := func(format string, args ...interface{}) sidebarLine {
+		return sidebarLine{text: fmt.Sprintf(format, args...), style: tcell.StyleDefault}
+	}
+
+	lines := []sidebarLine{
+		{text: c.Name, style: tcell.StyleDefault.Bold(true)},
+		{text: "Health " + bar(b.Condition()), style: d.colorStyle(conditionColor(b.Condition()))},
+	}
+	if b.MaxBlood > 0 {
+		blood := float64(b.Blood) / float64(b.MaxBlood)
+		lines = append(lines, sidebarLine{text: "Blood  " + bar(blood), style: d.colorStyle(color.Red)})
+	}
+	lines = append(lines,
+		plain("Str %2d  Agi %2d", c.Attributes.Strength, c.Attributes.Agility),
+		plain("Tou %2d  Per %2d", c.Attributes.Toughness, c.Attributes.Perception),
+		plain("Turn %d", g.Turns.Now()/game.TurnTicks),
+		plain(""),
+	)
+
+	status := []string{}
+	if b.Bleeding > 0 {
+		status = append(status, "Bleeding")
+	}
+	for _, s := range []string{c.HungerStatus(), c.ThirstStatus(), c.FatigueStatus()} {
+		if s != "" {
+			status = append(status, s)
+		}
+	}
+	if len(status) > 0 {
+		lines = append(lines, sidebarLine{text: strings.Join(status, " "), style: d.colorStyle(color.Yellow)})
+	}
+
+	for i := range b.Plan.Parts {
+		if b.Damage[i] == 0 {
+			continue
+		}
+		cond := 1.0 - float64(b.Damage[i])/float64(b.Health[i])
+		text := fmt.Sprintf("%s: %s", b.PartName(i), partCondition(cond))
+		lines = append(lines, sidebarLine{text: text, style: d.colorStyle(conditionColor(cond))})
+	}
+
+	lines = append(lines, plain(""))
+	if w := g.Equipment.Weapon; w != nil {
+		lines = append(lines, plain("Wielding %s", w.Name(g.Materials)))
+	}
+	for i := range g.Equipment.Worn {
+		lines = append(lines, plain("Wearing %s", g.Equipment.Worn[i].Name(g.Materials)))
+	}
+
+	for i := 0; i < height; i++ {
+		d.clearRegion(x, y+i, d.width-x)
+		if i < len(lines) {
+			d.drawString(x, y+i, lines[i].text, lines[i].style)
+		}
+	}
+	if height-len(lines) > minimapHeight {
+		d.drawMinimap(g, x, y+height-minimapHeight, sidebarWidth)
+	}
+}
+
+// bar returns a bar showing the given fraction, from 0.0 to 1.0.
+func bar(f float64) string {
+	n := int(f*barWidth + 0.5)
+	if n < 0 {
+		n = 0
+	} else if n > barWidth {
+		n = barWidth
+	}
+	return "[" + strings.Repeat("#", n) + strings.Repeat("-", barWidth-n) + "]"
+}
+
+// partCondition returns a description of a body part in the given
+// condition, from 0.0 (destroyed) to 1.0 (unharmed).
+func partCondition(cond float64) string {
+	switch {
+	case cond <= 0:
+		return "mangled"
+	case cond <= 0.33:
+		return "badly wounded"
+	case cond <= 0.66:
+		return "wounded"
+	}
+	return "bruised"
+}
+
+// conditionColor returns the color a condition, from 0.0 to 1.0, is shown
+// in.
+func conditionColor(cond float64) color.Enum {
+	switch {
+	case cond <= 0.33:
+		return color.BrightRed
+	case cond <= 0.66:
+		return color.Yellow
+	}
+	return color.Green
+}
@@ -0,0 +1,95 @@
+package terminal
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/light"
+)
+
+// minBrightness is the brightness of a visible tile with the lowest light
+// level, as a fraction of the full color value.
+const minBrightness = 0.3
+
+// depthBrightness is the brightness of a tile seen through open air at the
+// deepest level which can be seen, as a fraction of its color value.
+const depthBrightness = 0.35
+
+// trueColors is the number of colors of a terminal which supports 24-bit
+// color.
+const trueColors = 1 << 24
+
+// color returns the value of the color in the palette the driver draws with.
+func (d *Driver) color(c color.Enum) tcell.Color {
+	return tcell.NewHexColor(int32(d.palette.Value(c)))
+}
+
+// colorStyle returns a style with the foreground set to the given color.
+func (d *Driver) colorStyle(c color.Enum) tcell.Style {
+	return tcell.StyleDefault.Foreground(d.color(c))
+}
+
+// mapStyle returns the style with f applied to each of its RGB colors.
+//
+// Colors which are not RGB colors (e.g. the terminal default) are left as
+// they are.
+func mapStyle(s tcell.Style, f func(uint32) uint32) tcell.Style {
+	fg, bg, _ := s.Decompose()
+	if isRGB(fg) {
+		s = s.Foreground(tcell.NewHexColor(int32(f(uint32(fg.Hex())))))
+	}
+	if isRGB(bg) {
+		s = s.Background(tcell.NewHexColor(int32(f(uint32(bg.Hex())))))
+	}
+	return s
+}
+
+// faded is the color transformation used for remembered tiles.
+func faded(v uint32) uint32 {
+	return color.Scale(color.Desaturate(v, 0.75), 0.6)
+}
+
+// lit returns the color transformation for tiles with the given light level.
+func lit(l light.Level) func(uint32) uint32 {
+	f := minBrightness + (1.0-minBrightness)*float64(l)/float64(light.Max)
+	return func(v uint32) uint32 {
+		return color.Scale(v, f)
+	}
+}
+
+// deeper returns the color transformation for tiles seen through open air the
+// given number of levels below the level shown.
+func deeper(depth int) func(uint32) uint32 {
+	f := 1.0 - (1.0-depthBrightness)*float64(depth)/float64(game.ViewDepth)
+	return func(v uint32) uint32 {
+		return color.Scale(v, f)
+	}
+}
+
+// setContent sets the rune and style of a cell of the screen, with the
+// colors of the style mapped to those the driver draws with.
+func (d *Driver) setContent(x, y int, r rune, style tcell.Style) {
+	fg, bg, _ := style.Decompose()
+	style = style.Foreground(d.paletteColor(fg)).Background(d.paletteColor(bg))
+	d.screen.SetContent(x, y, r, nil, style)
+}
+
+// paletteColor returns the color c as drawn with the number of colors the
+// driver uses; RGB colors are mapped to the nearest color of the xterm
+// 256-color palette or the 16 ANSI colors if the driver can't draw them.
+func (d *Driver) paletteColor(c tcell.Color) tcell.Color {
+	if !isRGB(c) || d.colors >= trueColors {
+		return c
+	}
+	if d.colors >= 256 {
+		return tcell.Color(color.Xterm256(uint32(c.Hex())))
+	}
+	return tcell.Color(color.ANSI16(uint32(c.Hex())))
+}
+
+// isRGB returns true if c is an RGB color, rather than a palette color or the
+// terminal default. ColorDefault has every bit set, so it must be checked for
+// separately.
+func isRGB(c tcell.Color) bool {
+	return c != tcell.ColorDefault && c&tcell.ColorIsRGB != 0
+}
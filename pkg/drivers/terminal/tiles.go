@@ -1,52 +1,120 @@
 package terminal
 
 import (
+	"github.com/tvarney/grogue/pkg/game"
 	"github.com/tvarney/grogue/pkg/game/tile"
 )
 
 // Displayer is the interface all tile displayers must implement.
+//
+// The neighborhood gives access to the tiles around the one being displayed,
+// and is nil when displaying something other than a map tile.
 type Displayer interface {
-	Rune(cx, cy int, x, y uint16, t *tile.State) rune
+	Rune(cx, cy int, x, y uint16, t *tile.State, n Neighborhood) rune
+}
+
+// Neighborhood gives displayers access to the tiles around a tile.
+type Neighborhood interface {
+	// Neighbor returns the tile at the offset (dx,dy) from the tile being
+	// displayed, as the player knows it; on the same z-level when looking
+	// down on the map, or in the same vertical slice in the side view. If
+	// the tile is unknown or outside of the loaded world, this returns nil.
+	Neighbor(dx, dy int) *tile.State
+}
+
+// neighborhood is the Neighborhood of a map position, using the current
+// state of tiles the player can see and the remembered state of others.
+//
+// If side is true, the position is drawn in the side view, and the
+// neighborhood is the vertical x/z plane through it, with dy of -1 being the
+// level above.
+type neighborhood struct {
+	g    *game.Game
+	pos  game.Coords
+	side bool
+}
+
+func (n neighborhood) Neighbor(dx, dy int) *tile.State {
+	at := n.pos.Offset(dx, dy, 0)
+	if n.side {
+		at = n.pos.Offset(dx, 0, -dy)
+	}
+	if n.g.IsVisible(at) {
+		return n.g.Tile(at)
+	}
+	return n.g.Recall(at)
 }
 
 // Simple is a displayer which only ever returns a single rune.
 type Simple rune
 
-func (s Simple) Rune(cx, cy int, x, y uint16, t *tile.State) rune {
+func (s Simple) Rune(cx, cy int, x, y uint16, t *tile.State, n Neighborhood) rune {
 	return rune(s)
 }
 
 // Random is a displayer which chooses a 'pseudo-random' tile to display based
-// on the coordinates.
+// on the random value of the tile, or its coordinates if there is no tile.
 type Random []rune
 
-func (r Random) Rune(cx, cy int, x, y uint16, t *tile.State) rune {
+func (r Random) Rune(cx, cy int, x, y uint16, t *tile.State, n Neighborhood) rune {
+	if t == nil {
+		return r[(int(x)*7+int(y)*13)%len(r)]
+	}
 	return r[int(t.Random)%len(r)]
 }
 
 // LiquidNumber is a displayer for liquids which shows their depth.
 type LiquidNumber struct{}
 
-func (l LiquidNumber) Rune(cx, cy int, x, y uint16, t *tile.State) rune {
+func (l LiquidNumber) Rune(cx, cy int, x, y uint16, t *tile.State, n Neighborhood) rune {
 	return rune(uint32('0') + uint32(t.Liquid&0x0007))
 }
 
-func DefaultBlocks() []Displayer {
-	return []Displayer{
-		Simple(' '),
-		Simple('█'),
-		Simple('▓'),
-		Simple('█'),
-		Simple('█'),
+// The directions a wall may join in, as bits of the index into Wall.
+const (
+	joinNorth = 1 << iota
+	joinEast
+	joinSouth
+	joinWest
+)
+
+// Wall is a displayer which joins walls to the walls next to them.
+//
+// The rune is chosen by the directions the wall joins in, with the bits of
+// the index set by the joinNorth, joinEast, joinSouth, and joinWest
+// directions; index 0 is a wall which joins nothing. A wall joins a
+// neighboring solid block only if that block borders open space, so that
+// walls are drawn along the edges of rooms and tunnels rather than through
+// solid rock.
+type Wall [16]rune
+
+func (w Wall) Rune(cx, cy int, x, y uint16, t *tile.State, n Neighborhood) rune {
+	if n == nil {
+		return w[0]
 	}
+	mask := 0
+	for i, dir := range [4][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+		if solid(n.Neighbor(dir[0], dir[1])) && exposed(n, dir[0], dir[1]) {
+			mask |= 1 << i
+		}
+	}
+	return w[mask]
+}
+
+// solid returns true if the tile is known and holds a solid block.
+func solid(t *tile.State) bool {
+	return t != nil && !t.Passable()
 }
 
-func DefaultFloors() []Displayer {
-	return []Displayer{
-		Simple(' '),
-		Simple('.'),
-		Simple('.'),
-		Simple('.'),
-		Simple('.'),
+// exposed returns true if any tile around the tile at the offset (dx,dy) is
+// known and not solid.
+func exposed(n Neighborhood, dx, dy int) bool {
+	for oy := -1; oy <= 1; oy++ {
+		for ox := -1; ox <= 1; ox++ {
+			if t := n.Neighbor(dx+ox, dy+oy); t != nil && !solid(t) {
+				return true
+			}
+		}
 	}
+	return false
 }
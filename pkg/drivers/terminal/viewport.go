@@ -0,0 +1,156 @@
+package terminal
+
+import (
+	"github.com/tvarney/grogue/pkg/game"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+)
+
+const (
+	// sidebarWidth is the width of the panel to the right of the map.
+	sidebarWidth = 28
+
+	// statusLines is the number of lines between the map and the messages.
+	statusLines = 2
+)
+
+// layout is the arrangement of the game screen.
+//
+// The map fills the top left of the screen, with the sidebar to its right
+// and the status lines and messages below it. If sidebar is false, there is
+// no room for the sidebar and the map takes the full width of the screen.
+type layout struct {
+	mapWidth  int
+	mapHeight int
+	sidebar   bool
+	sidebarX  int
+	statusY   int
+}
+
+// layout returns the arrangement of the game screen for the current size of
+// the terminal.
+//
+// If the terminal is too narrow for both the map and the sidebar, the map
+// takes the full width and the sidebar isn't shown.
+func (d *Driver) layout() layout {
+	l := layout{
+		mapWidth:  d.width - sidebarWidth - 1,
+		mapHeight: d.height - statusLines - messageLines,
+		sidebar:   true,
+	}
+	if l.mapWidth < sidebarWidth {
+		l.mapWidth = d.width
+		l.sidebar = false
+	}
+	if l.mapHeight < 1 {
+		l.mapHeight = 1
+	}
+	l.sidebarX = l.mapWidth + 1
+	l.statusY = l.mapHeight
+	return l
+}
+
+// viewport maps world-space positions to positions on the screen.
+//
+// A top-down viewport shows a single z-level, while a side viewport shows a
+// vertical slice of the world with a single y position, highest z-level at
+// the top.
+type viewport struct {
+	// x, y, and z are the world-space position of the top-left tile shown.
+	// For a top-down viewport z is the z-level shown, and for a side viewport
+	// y is the position of the slice shown.
+	x, y, z int
+	side    bool
+
+	// sx and sy are the screen position the top-left tile is drawn at, and
+	// width and height are the number of tiles shown.
+	sx, sy        int
+	width, height int
+}
+
+// newViewport returns a viewport of the given size, drawn at (sx,sy), which
+// is centered on the focus.
+//
+// The viewport stops scrolling at the edges of the loaded world, so that as
+// much of the world as possible is shown. If the world is smaller than the
+// viewport, the world is centered in it instead.
+func newViewport(g *game.Game, focus game.Coords, sx, sy, width, height int) viewport {
+	fx, fy := focus.Global()
+	bx, by, bw, bh := g.Bounds()
+	return viewport{
+		x:      scroll(fx, width, bx, bw),
+		y:      scroll(fy, height, by, bh),
+		z:      focus.Z,
+		sx:     sx,
+		sy:     sy,
+		width:  width,
+		height: height,
+	}
+}
+
+// newSideViewport returns a side viewport of the given size, drawn at
+// (sx,sy), which is centered on the focus.
+//
+// Horizontally, this scrolls in the same way as newViewport; vertically, it
+// stops scrolling at the top and bottom of the world.
+func newSideViewport(g *game.Game, focus game.Coords, sx, sy, width, height int) viewport {
+	fx, fy := focus.Global()
+	bx, _, bw, _ := g.Bounds()
+	top := chunk.Height - 1 - scroll(chunk.Height-1-focus.Z, height, 0, chunk.Height)
+	return viewport{
+		x:      scroll(fx, width, bx, bw),
+		y:      fy,
+		z:      top,
+		side:   true,
+		sx:     sx,
+		sy:     sy,
+		width:  width,
+		height: height,
+	}
+}
+
+// scroll returns the first world-space position shown along an axis by a
+// view of the given size centered on f, where the world covers the extent
+// positions starting at start.
+func scroll(f, size, start, extent int) int {
+	if extent <= size {
+		return start - (size-extent)/2
+	}
+	first := f - size/2
+	if first < start {
+		first = start
+	}
+	if first+size > start+extent {
+		first = start + extent - size
+	}
+	return first
+}
+
+// screen returns the screen position of the coordinates, or false if they
+// aren't shown by the viewport.
+func (v *viewport) screen(c game.Coords) (int, int, bool) {
+	x, y := c.Global()
+	if v.side {
+		if y != v.y {
+			return 0, 0, false
+		}
+		y = v.z - c.Z
+	} else {
+		if c.Z != v.z {
+			return 0, 0, false
+		}
+		y -= v.y
+	}
+	x -= v.x
+	if x < 0 || y < 0 || x >= v.width || y >= v.height {
+		return 0, 0, false
+	}
+	return v.sx + x, v.sy + y, true
+}
+
+// world returns the coordinates shown at the given screen position.
+func (v *viewport) world(sx, sy int) game.Coords {
+	if v.side {
+		return game.GlobalCoords(v.x+sx-v.sx, v.y, v.z-(sy-v.sy))
+	}
+	return game.GlobalCoords(v.x+sx-v.sx, v.y+sy-v.sy, v.z)
+}
@@ -0,0 +1,97 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tvarney/grogue/pkg/game"
+)
+
+func TestLayout(t *testing.T) {
+	t.Parallel()
+	d := New()
+	d.width, d.height = 100, 40
+	l := d.layout()
+	assert.Equal(t, layout{
+		mapWidth:  100 - sidebarWidth - 1,
+		mapHeight: 40 - statusLines - messageLines,
+		sidebar:   true,
+		sidebarX:  100 - sidebarWidth,
+		statusY:   40 - statusLines - messageLines,
+	}, l)
+
+	// Too narrow for the sidebar
+	d.width, d.height = 50, 4
+	l = d.layout()
+	assert.False(t, l.sidebar)
+	assert.Equal(t, 50, l.mapWidth)
+	assert.Equal(t, 1, l.mapHeight)
+	assert.Equal(t, 1, l.statusY)
+}
+
+func TestScroll(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name                   string
+		f, size, start, extent int
+		expected               int
+	}{
+		{"centered", 50, 10, 0, 100, 45},
+		{"start", 2, 10, 0, 100, 0},
+		{"end", 98, 10, 0, 100, 90},
+		{"negative", -60, 10, -96, 288, -65},
+		{"small world", 5, 20, 0, 10, -5},
+		{"exact", 5, 10, 0, 10, 0},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, scroll(tc.f, tc.size, tc.start, tc.extent))
+		})
+	}
+}
+
+func TestViewport(t *testing.T) {
+	t.Parallel()
+	t.Run("top", func(t *testing.T) {
+		t.Parallel()
+		v := viewport{x: -10, y: 20, z: 33, sx: 1, sy: 2, width: 20, height: 10}
+		c := game.GlobalCoords(-5, 25, 33)
+		sx, sy, ok := v.screen(c)
+		assert.True(t, ok)
+		assert.Equal(t, 6, sx)
+		assert.Equal(t, 7, sy)
+		assert.Equal(t, c, v.world(sx, sy))
+		assert.Equal(t, game.GlobalCoords(-10, 20, 33), v.world(1, 2))
+
+		for _, c := range []game.Coords{
+			game.GlobalCoords(-5, 25, 32),
+			game.GlobalCoords(-11, 25, 33),
+			game.GlobalCoords(10, 25, 33),
+			game.GlobalCoords(-5, 19, 33),
+			game.GlobalCoords(-5, 30, 33),
+		} {
+			_, _, ok := v.screen(c)
+			assert.False(t, ok, "%v", c)
+		}
+	})
+	t.Run("side", func(t *testing.T) {
+		t.Parallel()
+		v := viewport{x: 0, y: 7, z: 40, side: true, width: 20, height: 10}
+		c := game.GlobalCoords(3, 7, 36)
+		sx, sy, ok := v.screen(c)
+		assert.True(t, ok)
+		assert.Equal(t, 3, sx)
+		assert.Equal(t, 4, sy)
+		assert.Equal(t, c, v.world(sx, sy))
+
+		for _, c := range []game.Coords{
+			game.GlobalCoords(3, 8, 36),
+			game.GlobalCoords(3, 7, 41),
+			game.GlobalCoords(3, 7, 30),
+		} {
+			_, _, ok := v.screen(c)
+			assert.False(t, ok, "%v", c)
+		}
+	})
+}
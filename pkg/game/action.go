@@ -1,5 +1,7 @@
 package game
 
+import "fmt"
+
 // Action is an enumeration of actions which the Application handles.
 //
 // These values are expected to be translated from keyboard and mouse events
@@ -13,9 +15,28 @@ const (
 	ActionMoveSouth
 	ActionMoveEast
 	ActionMoveWest
+	ActionMoveNorthEast
+	ActionMoveNorthWest
+	ActionMoveSouthEast
+	ActionMoveSouthWest
 	ActionMoveUp
 	ActionMoveDown
 	ActionWait
+	ActionTravel
+	ActionLook
+	ActionExplore
+	ActionContinue
+	ActionInterrupt
+	ActionMessageLog
+	ActionPickUp
+	ActionInventory
+	ActionCraft
+	ActionDrink
+	ActionRest
+	ActionSideView
+	ActionOverview
+	ActionZoomIn
+	ActionZoomOut
 	ActionMenuOpen
 
 	ActionMenuUp
@@ -24,4 +45,116 @@ const (
 	ActionMenuRight
 	ActionMenuSelect
 	ActionMenuClose
+	ActionMenuBackspace
+	ActionMenuDelete
+	ActionMenuHome
+	ActionMenuEnd
+
+	actionCount
 )
+
+var actionNames = map[Action]string{
+	ActionQuit:          "quit",
+	ActionNone:          "none",
+	ActionMoveNorth:     "move-north",
+	ActionMoveSouth:     "move-south",
+	ActionMoveEast:      "move-east",
+	ActionMoveWest:      "move-west",
+	ActionMoveNorthEast: "move-north-east",
+	ActionMoveNorthWest: "move-north-west",
+	ActionMoveSouthEast: "move-south-east",
+	ActionMoveSouthWest: "move-south-west",
+	ActionMoveUp:        "move-up",
+	ActionMoveDown:      "move-down",
+	ActionWait:          "wait",
+	ActionTravel:        "travel",
+	ActionLook:          "look",
+	ActionExplore:       "explore",
+	ActionContinue:      "continue",
+	ActionInterrupt:     "interrupt",
+	ActionMessageLog:    "message-log",
+	ActionPickUp:        "pick-up",
+	ActionInventory:     "inventory",
+	ActionCraft:         "craft",
+	ActionDrink:         "drink",
+	ActionRest:          "rest",
+	ActionSideView:      "side-view",
+	ActionOverview:      "overview",
+	ActionZoomIn:        "zoom-in",
+	ActionZoomOut:       "zoom-out",
+	ActionMenuOpen:      "menu-open",
+	ActionMenuUp:        "menu-up",
+	ActionMenuDown:      "menu-down",
+	ActionMenuLeft:      "menu-left",
+	ActionMenuRight:     "menu-right",
+	ActionMenuSelect:    "menu-select",
+	ActionMenuClose:     "menu-close",
+	ActionMenuBackspace: "menu-backspace",
+	ActionMenuDelete:    "menu-delete",
+	ActionMenuHome:      "menu-home",
+	ActionMenuEnd:       "menu-end",
+}
+
+// ParseAction returns the action with the given name, as returned by
+// Action.String.
+func ParseAction(name string) (Action, bool) {
+	for a, n := range actionNames {
+		if n == name {
+			return a, true
+		}
+	}
+	return ActionNone, false
+}
+
+// String returns the name of the action.
+func (a Action) String() string {
+	if name, ok := actionNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("action-%d", int(a))
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the action by name.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the action from
+// its name.
+func (a *Action) UnmarshalText(text []byte) error {
+	act, ok := ParseAction(string(text))
+	if !ok {
+		return fmt.Errorf("unknown action %q", text)
+	}
+	*a = act
+	return nil
+}
+
+// Direction returns the movement deltas of a movement action.
+//
+// If the action is not a movement action, this returns false.
+func (a Action) Direction() (dx, dy, dz int, ok bool) {
+	switch a {
+	case ActionMoveNorth:
+		return 0, -1, 0, true
+	case ActionMoveSouth:
+		return 0, 1, 0, true
+	case ActionMoveEast:
+		return 1, 0, 0, true
+	case ActionMoveWest:
+		return -1, 0, 0, true
+	case ActionMoveNorthEast:
+		return 1, -1, 0, true
+	case ActionMoveNorthWest:
+		return -1, -1, 0, true
+	case ActionMoveSouthEast:
+		return 1, 1, 0, true
+	case ActionMoveSouthWest:
+		return -1, 1, 0, true
+	case ActionMoveUp:
+		return 0, 0, 1, true
+	case ActionMoveDown:
+		return 0, 0, -1, true
+	}
+	return 0, 0, 0, false
+}
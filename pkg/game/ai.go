@@ -0,0 +1,204 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/creature"
+)
+
+const (
+	// herdDistance is the distance a herd creature strays from the nearest
+	// member of its herd before moving back towards it.
+	herdDistance = 3
+
+	// chaseSearch is the number of tiles a creature searches when finding a
+	// path to its target.
+	chaseSearch = 400
+)
+
+// Act runs a single turn of the creature, returning the movement cost of
+// what it did.
+func (g *Game) Act(cr *Creature) int {
+	sp := g.SpeciesOf(cr)
+	if cr.Hostile && g.senses(cr, g.Player) {
+		return g.approach(cr, g.Player)
+	}
+	switch sp.Behavior {
+	case creature.Still:
+		return CostHorizontal
+	case creature.Flee:
+		if threat, ok := g.nearestThreat(cr); ok {
+			return g.flee(cr, threat)
+		}
+	case creature.Hunt:
+		if prey, ok := g.nearestPrey(cr); ok {
+			return g.approach(cr, prey)
+		}
+	case creature.Herd:
+		if threat, ok := g.nearestThreat(cr); ok {
+			return g.flee(cr, threat)
+		}
+		if other, ok := g.nearestHerd(cr); ok && distance(cr.Pos, other) > herdDistance {
+			return g.approach(cr, other)
+		}
+	}
+	return g.wander(cr)
+}
+
+// distance returns the number of steps between two positions, ignoring the
+// z-level.
+func distance(a, b Coords) int {
+	ax, ay := a.Global()
+	bx, by := b.Global()
+	dx, dy := abs(ax-bx), abs(ay-by)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// senses returns true if the creature notices something at the given
+// position.
+func (g *Game) senses(cr *Creature, c Coords) bool {
+	return c.Z == cr.Pos.Z && distance(cr.Pos, c) <= g.SpeciesOf(cr).Sight
+}
+
+// nearest returns the position of the nearest sensed creature matching the
+// filter, including the player if player is true.
+func (g *Game) nearest(cr *Creature, player bool, match func(*creature.Species) bool) (Coords, bool) {
+	var best Coords
+	found := false
+	consider := func(c Coords) {
+		if !g.senses(cr, c) {
+			return
+		}
+		if !found || distance(cr.Pos, c) < distance(cr.Pos, best) {
+			best, found = c, true
+		}
+	}
+	if player {
+		consider(g.Player)
+	}
+	for _, o := range g.Creatures {
+		if o != cr && match(g.SpeciesOf(o)) {
+			consider(o.Pos)
+		}
+	}
+	return best, found
+}
+
+// nearestThreat returns the position of the nearest threat to the creature;
+// the player, or a creature which preys on it.
+func (g *Game) nearestThreat(cr *Creature) (Coords, bool) {
+	sp := g.SpeciesOf(cr)
+	return g.nearest(cr, true, func(o *creature.Species) bool { return o.Preys(sp) })
+}
+
+// nearestPrey returns the position of the nearest prey of the creature;
+// the player, or a creature it preys on.
+func (g *Game) nearestPrey(cr *Creature) (Coords, bool) {
+	sp := g.SpeciesOf(cr)
+	return g.nearest(cr, true, sp.Preys)
+}
+
+// nearestHerd returns the position of the nearest creature of the same
+// species.
+func (g *Game) nearestHerd(cr *Creature) (Coords, bool) {
+	sp := g.SpeciesOf(cr)
+	return g.nearest(cr, false, func(o *creature.Species) bool { return o == sp })
+}
+
+// canMove returns true if the creature may make the step s.
+//
+// Creatures don't use stairs, swim, or leave their habitat, and can't move
+// into a tile another creature or the player is in.
+func (g *Game) canMove(cr *Creature, s Step) bool {
+	if s.DX == 0 && s.DY == 0 {
+		return false
+	}
+	to := cr.Pos.Offset(s.DX, s.DY, s.DZ)
+	if !g.CanStep(cr.Pos, s) || g.Tile(to).Liquid > 0 || g.Occupied(to) {
+		return false
+	}
+	return g.HabitatAt(to) == g.SpeciesOf(cr).Habitat
+}
+
+// move makes the step s, returning its movement cost.
+func (g *Game) move(cr *Creature, s Step) int {
+	cost := g.MoveCost(cr.Pos, s)
+	cr.Pos = cr.Pos.Offset(s.DX, s.DY, s.DZ)
+	return cost
+}
+
+// wander moves the creature in a random direction, or has it stay still.
+func (g *Game) wander(cr *Creature) int {
+	if g.Rand.Intn(3) == 0 {
+		return CostHorizontal
+	}
+	s := Steps[g.Rand.Intn(len(Steps))]
+	if !g.canMove(cr, s) {
+		return CostHorizontal
+	}
+	return g.move(cr, s)
+}
+
+// flee moves the creature as far away from the threat as a single step
+// allows.
+func (g *Game) flee(cr *Creature, threat Coords) int {
+	tx, ty := threat.Global()
+	best, bestDist := Step{}, distanceSq(cr.Pos, tx, ty)
+	for _, s := range Steps {
+		if !g.canMove(cr, s) {
+			continue
+		}
+		if d := distanceSq(cr.Pos.Offset(s.DX, s.DY, s.DZ), tx, ty); d > bestDist {
+			best, bestDist = s, d
+		}
+	}
+	if best == (Step{}) {
+		return CostHorizontal
+	}
+	return g.move(cr, best)
+}
+
+// approach moves the creature a step towards the target.
+//
+// If the creature is already next to the target, it attacks the target
+// instead.
+func (g *Game) approach(cr *Creature, target Coords) int {
+	if distance(cr.Pos, target) <= 1 && cr.Pos.Z == target.Z {
+		if g.SpeciesOf(cr).Attack.Damage > 0 {
+			g.CreatureAttack(cr, target)
+		}
+		return CostHorizontal
+	}
+	path, err := g.FindPath(cr.Pos, target, PathOptions{MaxSearch: chaseSearch})
+	if err == nil && len(path) > 0 {
+		px, py := cr.Pos.Global()
+		nx, ny := path[0].Global()
+		if s := (Step{DX: nx - px, DY: ny - py, DZ: path[0].Z - cr.Pos.Z}); g.canMove(cr, s) {
+			return g.move(cr, s)
+		}
+	}
+
+	// Fall back to the step which gets closest to the target
+	tx, ty := target.Global()
+	best, bestDist := Step{}, distanceSq(cr.Pos, tx, ty)
+	for _, s := range Steps {
+		if !g.canMove(cr, s) {
+			continue
+		}
+		if d := distanceSq(cr.Pos.Offset(s.DX, s.DY, s.DZ), tx, ty); d < bestDist {
+			best, bestDist = s, d
+		}
+	}
+	if best == (Step{}) {
+		return CostHorizontal
+	}
+	return g.move(cr, best)
+}
+
+// distanceSq returns the squared distance from c to the world-space position
+// (x,y).
+func distanceSq(c Coords, x, y int) int {
+	cx, cy := c.Global()
+	return (cx-x)*(cx-x) + (cy-y)*(cy-y)
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/creature"
+)
+
+// newTestGame returns a game with a flat world and no creatures, with the
+// player standing at the center of chunk (0,0).
+//
+// Tests which need caves, stairs, or creatures should call GenerateWorld.
+func newTestGame(tb testing.TB) *Game {
+	tb.Helper()
+	log.SetOutput(io.Discard)
+	g := New(1).Game
+	list := g.ChunkCoordsList()
+	g.ActiveChunks = make([]*chunk.Chunk, len(list))
+	for _, cc := range list {
+		g.ActiveChunks[g.chunkIndex(cc)] = g.Generator.Flat(int64(cc.X), int64(cc.Y))
+	}
+	g.Player = Coords{X: chunk.Width / 2, Y: chunk.Length / 2, Z: chunk.SurfaceLevel}
+	return g
+}
+
+// speciesID returns the ID of the species with the given name.
+func speciesID(tb testing.TB, g *Game, name string) creature.SpeciesID {
+	tb.Helper()
+	for i := range g.Species {
+		if g.Species[i].Name == name {
+			return creature.SpeciesID(i)
+		}
+	}
+	tb.Fatalf("no species named %q", name)
+	return 0
+}
+
+func TestAct(t *testing.T) {
+	t.Parallel()
+	t.Run("flee", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		cr := g.AddCreature(speciesID(t, g, "rabbit"), g.Player.Offset(3, 0, 0))
+		for i := 0; i < 3; i++ {
+			g.Act(cr)
+		}
+		assert.Equal(t, 6, distance(g.Player, cr.Pos))
+	})
+	t.Run("hunt", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		cr := g.AddCreature(speciesID(t, g, "wolf"), g.Player.Offset(-6, 4, 0))
+		for i := 0; i < 10; i++ {
+			g.Act(cr)
+		}
+		assert.Equal(t, 1, distance(g.Player, cr.Pos))
+	})
+	t.Run("herd", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.Player = g.Player.Offset(0, 0, 10)
+		deer := speciesID(t, g, "deer")
+		a := g.AddCreature(deer, g.Player.Offset(-6, 0, -10))
+		b := g.AddCreature(deer, g.Player.Offset(6, 0, -10))
+		for i := 0; i < 10; i++ {
+			g.Act(a)
+			g.Act(b)
+		}
+		assert.LessOrEqual(t, distance(a.Pos, b.Pos), herdDistance+1)
+	})
+}
+
+func TestSpawnCreatures(t *testing.T) {
+	t.Parallel()
+	g := newTestGame(t)
+	g.GenerateWorld()
+	require.NotEmpty(t, g.Creatures)
+
+	// Creatures are placed at the positions chosen when their chunk was
+	// generated, in the habitat of their species
+	for _, cr := range g.Creatures {
+		spawned := false
+		for _, s := range g.Chunk(cr.Pos.Chunk).Spawns {
+			spawned = spawned || (Coords{X: s.X, Y: s.Y, Z: s.Z, Chunk: cr.Pos.Chunk} == cr.Pos)
+		}
+		assert.True(t, spawned, "%s at %v", g.SpeciesOf(cr).Name, cr.Pos)
+		assert.Equal(t, g.SpeciesOf(cr).Habitat, g.HabitatAt(cr.Pos))
+	}
+
+	// The same seed places the same creatures
+	other := newTestGame(t)
+	other.GenerateWorld()
+	require.Len(t, other.Creatures, len(g.Creatures))
+	for i, cr := range other.Creatures {
+		assert.Equal(t, g.Creatures[i].Pos, cr.Pos)
+	}
+}
@@ -2,9 +2,16 @@ package game
 
 import (
 	"log"
+	"math/rand"
 
+	"github.com/tvarney/grogue/pkg/game/body"
 	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/craft"
+	"github.com/tvarney/grogue/pkg/game/creature"
 	"github.com/tvarney/grogue/pkg/game/material"
+	"github.com/tvarney/grogue/pkg/game/message"
+	"github.com/tvarney/grogue/pkg/game/schedule"
 	"github.com/tvarney/grogue/pkg/game/tile"
 )
 
@@ -15,10 +22,40 @@ type Application struct {
 	InGame  bool
 	Game    *Game
 
+	// SummaryDir is the directory summaries of finished games are written
+	// to. If this is empty, no summaries are written.
+	SummaryDir string
+
+	// Settings are the user's preferences, and SettingsPath is the file they
+	// are saved to when changed. If SettingsPath is empty, the settings
+	// aren't saved.
+	Settings     Settings
+	SettingsPath string
+
+	// Palette holds the colors drivers draw with, as chosen by the settings.
+	Palette color.Palette
+
+	// Keymap holds the key bindings used by drivers, and KeymapPath is the
+	// file they are saved to when changed. If KeymapPath is empty, the key
+	// bindings aren't saved.
+	Keymap     Keymap
+	KeymapPath string
+
+	// NewGame holds the choices made during character creation.
+	NewGame NewGameOptions
+
 	menu  []Menu
 	menus map[string]Menu
+
+	// keyContext and keyAction are the context and action being edited in
+	// the key binding menus.
+	keyContext string
+	keyAction  Action
 }
 
+// MessageLimit is the number of messages kept in the message log.
+const MessageLimit = 500
+
 // New returns a new Application instance.
 func New(seed int64) *Application {
 	mats := material.DefaultMaterials()
@@ -28,17 +65,52 @@ func New(seed int64) *Application {
 		Running: true,
 		InGame:  false,
 		Game: &Game{
-			Materials: mats,
-			Blocks:    blocks,
-			Floors:    floors,
-			Generator: chunk.NewGenerator(seed, mats),
+			Materials:   mats,
+			Blocks:      blocks,
+			Floors:      floors,
+			Recipes:     craft.DefaultRecipes(),
+			Backgrounds: DefaultBackgrounds(),
+			Species:     creature.DefaultSpecies(),
+			Plans:       body.DefaultPlans(),
+			Messages:    message.NewLog(MessageLimit),
+			PlayerLight: DefaultPlayerLight,
+			Seed:        seed,
+			WorldRadius: DefaultWorldRadius,
+			Generator:   chunk.NewGenerator(seed, mats),
+			Turns:       schedule.New(),
+			Rand:        rand.New(rand.NewSource(seed)),
+		},
+
+		Settings: DefaultSettings(),
+		Palette:  color.DefaultPalette(),
+		Keymap:   DefaultKeymap(),
+		NewGame: NewGameOptions{
+			Name:        DefaultPlayerName,
+			Seed:        seed,
+			WorldRadius: DefaultWorldRadius,
 		},
 
 		menu:  make([]Menu, 0, 10),
 		menus: map[string]Menu{},
 	}
 
+	app.Game.ResetPlayer()
+
 	app.AddMenu(NewMainMenu())
+	app.AddMenu(NewCharacterNameMenu())
+	app.AddMenu(NewCharacterBackgroundMenu())
+	app.AddMenu(NewWorldOptionsMenu())
+	app.AddMenu(NewWorldSeedMenu())
+	app.AddMenu(NewOptionsMenu())
+	app.AddMenu(NewKeyContextsMenu())
+	app.AddMenu(NewKeyBindingsMenu())
+	app.AddMenu(NewKeyCaptureMenu())
+	app.AddMenu(NewMessageLogMenu())
+	app.AddMenu(NewInventoryMenu())
+	app.AddMenu(NewItemMenu())
+	app.AddMenu(NewCraftMenu())
+	app.AddMenu(NewOverviewMenu())
+	app.AddMenu(NewDeathMenu())
 	app.PushMenu(MainMenuID)
 
 	return app
@@ -46,7 +118,26 @@ func New(seed int64) *Application {
 
 // Update takes an action from the game driver and updates the state to
 // reflect the results of that action.
+//
+// If the player died as a result of the action, the game is ended. If the
+// last menu was closed outside of a game, the application quits.
 func (a *Application) Update(action Action) RenderRequest {
+	ret := a.update(action)
+	if a.Running && !a.InGame && len(a.menu) == 0 {
+		log.Printf("game.Application::Update(): No menu and not in game; quitting")
+		a.Quit()
+		return RenderNoChange
+	}
+	if a.InGame && a.Game.Dead {
+		if m := a.GetMenu(); m == nil || m.GetID() != DeathMenuID {
+			a.EndGame()
+			return RenderFull
+		}
+	}
+	return ret
+}
+
+func (a *Application) update(action Action) RenderRequest {
 	// Unconditionally handle the quit signal
 	if action == ActionQuit {
 		log.Printf("game.Application::Update(): Handling ActionQuit")
@@ -68,20 +159,63 @@ func (a *Application) Update(action Action) RenderRequest {
 		return RenderNoChange
 	}
 
+	// Let the map cursor handle actions while it is shown
+	if a.Game.Cursor.Mode != CursorNone {
+		return a.UpdateCursor(action)
+	}
+
 	// Handle game actions
+	if dx, dy, dz, ok := action.Direction(); ok {
+		return a.UpdateMovePlayer(dx, dy, dz)
+	}
 	switch action {
-	case ActionMoveDown:
-		return a.UpdateMovePlayer(0, 0, -1)
-	case ActionMoveUp:
-		return a.UpdateMovePlayer(0, 0, 1)
-	case ActionMoveNorth:
-		return a.UpdateMovePlayer(0, -1, 0)
-	case ActionMoveSouth:
-		return a.UpdateMovePlayer(0, 1, 0)
-	case ActionMoveWest:
-		return a.UpdateMovePlayer(-1, 0, 0)
-	case ActionMoveEast:
-		return a.UpdateMovePlayer(1, 0, 0)
+	case ActionWait:
+		a.Game.Message(message.Info, "You wait.")
+		a.Game.EndTurn(TurnTicks)
+		return RenderIncremental
+	case ActionMessageLog:
+		a.PushMenu(MessageLogMenuID)
+		return RenderFull
+	case ActionPickUp:
+		a.Game.PickUp()
+		return RenderIncremental
+	case ActionInventory:
+		a.PushMenu(InventoryMenuID)
+		return RenderFull
+	case ActionCraft:
+		a.PushMenu(CraftMenuID)
+		return RenderFull
+	case ActionDrink:
+		a.Game.Drink()
+		return RenderIncremental
+	case ActionRest:
+		if a.Game.Character.Fatigue <= 0 {
+			a.Game.Message(message.Info, "You aren't tired.")
+			return RenderIncremental
+		}
+		a.Game.Message(message.Info, "You lie down to rest.")
+		a.Game.Auto = AutoMove{Mode: AutoRest}
+		return a.UpdateAuto()
+	case ActionSideView:
+		a.Game.ToggleMapView()
+		return RenderFull
+	case ActionOverview:
+		a.PushMenu(OverviewMenuID)
+		return RenderFull
+	case ActionTravel:
+		a.Game.OpenCursor(CursorTravel)
+		return RenderIncremental
+	case ActionLook:
+		a.Game.OpenCursor(CursorLook)
+		return RenderIncremental
+	case ActionExplore:
+		a.Game.Auto = AutoMove{Mode: AutoExplore}
+		return a.UpdateAuto()
+	case ActionContinue:
+		return a.UpdateAuto()
+	case ActionInterrupt:
+		a.Game.Interrupt()
+		return RenderIncremental
 	}
 	return RenderNoChange
 }
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"log"
+
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+// AutoMode is an enumeration of the kinds of automatic movement.
+type AutoMode int
+
+const (
+	AutoNone AutoMode = iota
+	AutoTravel
+	AutoExplore
+	AutoRest
+)
+
+// AutoMove is a multi-turn movement of the player.
+//
+// Automatic movement takes a single step each time the driver sends an
+// ActionContinue, and stops when the destination is reached or the movement
+// is interrupted.
+type AutoMove struct {
+	Mode   AutoMode
+	Target Coords
+}
+
+// Busy returns true if the player is moving automatically.
+//
+// Drivers should send ActionContinue instead of waiting for input while the
+// application is busy, and ActionInterrupt if the user presses a key.
+func (a *Application) Busy() bool {
+	return a.InGame && len(a.menu) == 0 && a.Game.Auto.Mode != AutoNone
+}
+
+// Interrupt stops any automatic movement of the player.
+//
+// This should be called whenever something happens that the player should
+// get the chance to react to.
+func (g *Game) Interrupt() {
+	if g.Auto.Mode != AutoNone {
+		log.Printf("game.Game::Interrupt(): Stopping automatic movement")
+	}
+	g.Auto = AutoMove{}
+}
+
+// Unexplored returns true if the tile at c has never been seen by the player.
+//
+// Tiles outside of the world are never unexplored.
+func (g *Game) Unexplored(c Coords) bool {
+	return g.Chunk(c.Chunk) != nil && g.Recall(c) == nil
+}
+
+// UpdateAuto moves the player a single step along their automatic movement.
+func (a *Application) UpdateAuto() RenderRequest {
+	g := a.Game
+	if g.Auto.Mode == AutoRest {
+		if !g.Rest() {
+			g.Message(message.Good, "You feel rested.")
+		}
+		return RenderIncremental
+	}
+	opts := PathOptions{KnownOnly: true}
+
+	var path []Coords
+	var err error
+	switch g.Auto.Mode {
+	case AutoTravel:
+		path, err = g.FindPath(g.Player, g.Auto.Target, opts)
+	case AutoExplore:
+		path, err = g.FindNearest(g.Player, g.Unexplored, opts)
+	default:
+		return RenderNoChange
+	}
+	if err != nil || len(path) == 0 {
+		log.Printf("game.Application::UpdateAuto(): Stopping; %d steps, error: %v", len(path), err)
+		switch {
+		case g.Auto.Mode == AutoExplore && err != nil:
+			g.Message(message.Info, "There is nothing left to explore nearby.")
+		case err != nil:
+			g.Message(message.Info, "You don't know of a way there.")
+		default:
+			g.Interrupt()
+		}
+		return RenderIncremental
+	}
+
+	px, py := g.Player.Global()
+	nx, ny := path[0].Global()
+	ret := a.UpdateMovePlayer(nx-px, ny-py, path[0].Z-g.Player.Z)
+	if ret == RenderNoChange || (g.Auto.Mode == AutoTravel && len(path) == 1) {
+		g.Interrupt()
+		return RenderIncremental
+	}
+	return ret
+}
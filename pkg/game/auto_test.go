@@ -0,0 +1,151 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+// newTestApp returns an application in the middle of a game in the flat
+// world of newTestGame.
+func newTestApp(tb testing.TB) *Application {
+	tb.Helper()
+	g := newTestGame(tb)
+	app := New(1)
+	app.Game = g
+	for app.GetMenu() != nil {
+		app.PopMenu()
+	}
+	app.InGame = true
+	app.Game.UpdateView()
+	return app
+}
+
+// rememberLevel has the player remember every tile on the z-level.
+func rememberLevel(g *Game, z int) {
+	for _, c := range g.ActiveChunks {
+		for y := 0; y < chunk.Length; y++ {
+			for x := 0; x < chunk.Width; x++ {
+				c.Remember(x, y, z)
+			}
+		}
+	}
+}
+
+// lastMessage returns the text of the most recent message, or an empty
+// string if there are no messages.
+func lastMessage(g *Game) string {
+	last := g.Messages.Last(1)
+	if len(last) == 0 {
+		return ""
+	}
+	return last[0].Text
+}
+
+// runAuto sends ActionContinue until the automatic movement stops, returning
+// the number of steps taken.
+func runAuto(tb testing.TB, app *Application) int {
+	tb.Helper()
+	n := 0
+	for ; app.Busy(); n++ {
+		require.Less(tb, n, 10000, "automatic movement never stopped")
+		app.Update(ActionContinue)
+	}
+	return n
+}
+
+func TestUpdateAuto(t *testing.T) {
+	t.Parallel()
+	t.Run("travel", func(t *testing.T) {
+		t.Parallel()
+		app := newTestApp(t)
+		g := app.Game
+		target := g.Player.Offset(8, -5, 0)
+		app.Update(ActionTravel)
+		g.Cursor.Pos = target
+		app.Update(ActionMenuSelect)
+		require.True(t, app.Busy())
+		runAuto(t, app)
+		assert.Equal(t, target, g.Player)
+	})
+	t.Run("travel unknown", func(t *testing.T) {
+		t.Parallel()
+		app := newTestApp(t)
+		g := app.Game
+		g.Auto = AutoMove{Mode: AutoTravel, Target: g.Player.Offset(0, 0, -5)}
+		start := g.Player
+		app.Update(ActionContinue)
+		assert.False(t, app.Busy())
+		assert.Equal(t, start, g.Player)
+		assert.Equal(t, "You don't know of a way there.", lastMessage(g))
+	})
+	t.Run("explore", func(t *testing.T) {
+		t.Parallel()
+		app := newTestApp(t)
+		g := app.Game
+		// Explore a world of a single chunk
+		g.WorldRadius = 0
+		g.ActiveChunks = []*chunk.Chunk{g.Generator.Flat(0, 0)}
+		g.UpdateView()
+
+		// Exploring is interrupted by creatures spawning nearby, so keep
+		// exploring until everything has been seen
+		const done = "There is nothing left to explore nearby."
+		steps := 0
+		for i := 0; i < 100 && lastMessage(g) != done; i++ {
+			app.Update(ActionExplore)
+			steps += runAuto(t, app)
+		}
+		assert.Equal(t, done, lastMessage(g))
+		assert.Positive(t, steps)
+		assert.False(t, app.Busy())
+		_, err := g.FindNearest(g.Player, g.Unexplored, PathOptions{KnownOnly: true})
+		assert.ErrorIs(t, err, ErrNoPath)
+	})
+	t.Run("creature", func(t *testing.T) {
+		t.Parallel()
+		app := newTestApp(t)
+		g := app.Game
+		rememberLevel(g, g.Player.Z)
+		start := g.Player
+		target := start.Offset(30, 0, 0)
+		dummy := g.AddCreature(speciesID(t, g, TrainingDummy), start.Offset(24, 2, 0))
+		require.False(t, g.IsVisible(dummy.Pos))
+
+		g.Auto = AutoMove{Mode: AutoTravel, Target: target}
+		runAuto(t, app)
+		assert.True(t, g.IsVisible(dummy.Pos))
+		assert.NotEqual(t, start, g.Player)
+		assert.NotEqual(t, target, g.Player)
+		assert.Equal(t, 1, countMessages(g, "You see a training dummy."))
+	})
+	t.Run("message", func(t *testing.T) {
+		t.Parallel()
+		app := newTestApp(t)
+		g := app.Game
+		g.Auto = AutoMove{Mode: AutoTravel, Target: g.Player.Offset(10, 0, 0)}
+		app.Update(ActionContinue)
+		require.True(t, app.Busy())
+		g.Message(message.Warning, "You hear a howl.")
+		assert.False(t, app.Busy())
+	})
+	t.Run("quiet", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.StartGame(app.NewGame)
+		require.True(t, app.InGame)
+
+		// The message ending the rest is discarded, but still ends it
+		app.Game.Verbosity = message.Danger
+		app.Game.Character.Fatigue = 0
+		app.Game.Auto = AutoMove{Mode: AutoRest}
+		require.True(t, app.Busy())
+		n := app.Game.Messages.Len()
+		app.Update(ActionContinue)
+		assert.False(t, app.Busy())
+		assert.Equal(t, n, app.Game.Messages.Len())
+	})
+}
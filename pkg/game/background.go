@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+// StartingItem is an item a character starts the game with.
+type StartingItem struct {
+	Kind item.Kind
+	// Material is the solid name of the material the item is made of.
+	Material string
+	Count    int
+}
+
+// Background is the profession a character had before the game started,
+// which determines their starting attributes and items.
+type Background struct {
+	Name        string
+	Description string
+
+	// Attributes are added to the default attributes of the character.
+	Attributes Attributes
+	Items      []StartingItem
+}
+
+// DefaultBackgrounds returns the list of backgrounds a character may be
+// created with.
+//
+// This is a placeholder function; eventually this will be replaced by reading
+// data files.
+func DefaultBackgrounds() []Background {
+	return []Background{
+		{
+			Name:        "Wanderer",
+			Description: "unremarkable, but well travelled",
+			Attributes:  Attributes{Agility: 1, Perception: 1},
+			Items: []StartingItem{
+				{Kind: item.Rock, Material: "stone", Count: 3},
+			},
+		},
+		{
+			Name:        "Miner",
+			Description: "strong, with a pick",
+			Attributes:  Attributes{Strength: 2, Toughness: 1, Perception: -1},
+			Items: []StartingItem{
+				{Kind: item.Pick, Material: "stone", Count: 1},
+				{Kind: item.Rock, Material: "stone", Count: 2},
+			},
+		},
+		{
+			Name:        "Hunter",
+			Description: "keen-eyed, with an axe",
+			Attributes:  Attributes{Strength: -1, Agility: 2, Perception: 2},
+			Items: []StartingItem{
+				{Kind: item.Axe, Material: "stone", Count: 1},
+				{Kind: item.Helmet, Material: "bone", Count: 1},
+				{Kind: item.Log, Material: "oak", Count: 2},
+			},
+		},
+		{
+			Name:        "Smith",
+			Description: "tough, with a hammer and metal",
+			Attributes:  Attributes{Strength: 1, Agility: -1, Toughness: 2},
+			Items: []StartingItem{
+				{Kind: item.Hammer, Material: "iron", Count: 1},
+				{Kind: item.Bar, Material: "iron", Count: 3},
+				{Kind: item.Bar, Material: "copper", Count: 2},
+			},
+		},
+	}
+}
+
+// Add returns the sum of the two sets of attributes.
+func (a Attributes) Add(b Attributes) Attributes {
+	return Attributes{
+		Strength:   a.Strength + b.Strength,
+		Agility:    a.Agility + b.Agility,
+		Toughness:  a.Toughness + b.Toughness,
+		Perception: a.Perception + b.Perception,
+	}
+}
+
+// Summary returns a short description of the background's attribute
+// modifiers, such as "Str +2, Per -1".
+func (b *Background) Summary() string {
+	mods := []struct {
+		name  string
+		value int
+	}{
+		{"Str", b.Attributes.Strength},
+		{"Agi", b.Attributes.Agility},
+		{"Tou", b.Attributes.Toughness},
+		{"Per", b.Attributes.Perception},
+	}
+	s := ""
+	for _, m := range mods {
+		if m.value == 0 {
+			continue
+		}
+		if s != "" {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s %+d", m.name, m.value)
+	}
+	return s
+}
+
+// materialByName returns the material with the given solid name.
+func (g *Game) materialByName(name string) (material.ID, bool) {
+	for i, m := range g.Materials {
+		if m.Solid.Name == name {
+			return material.ID(i), true
+		}
+	}
+	return 0, false
+}
+
+// GiveItems adds the starting items to the player's inventory.
+//
+// Items made of unknown materials are skipped.
+func (g *Game) GiveItems(items []StartingItem) {
+	for _, s := range items {
+		id, ok := g.materialByName(s.Material)
+		if !ok {
+			log.Printf("game.Game::GiveItems(): Unknown material %q", s.Material)
+			continue
+		}
+		it := item.New(s.Kind, id)
+		it.Count = s.Count
+		g.Inventory.Add(it)
+	}
+}
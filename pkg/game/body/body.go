@@ -0,0 +1,239 @@
+package body
+
+import (
+	"math"
+	"math/rand"
+)
+
+// PlanID is a body plan reference ID.
+//
+// This is functionally an index to the plan in the list of body plans.
+type PlanID uint8
+
+// PartDef is the definition of a single part of a body plan.
+type PartDef struct {
+	Name string
+
+	// Size is the relative chance of the part being hit by an attack.
+	Size int
+
+	// Health is the damage the part of a 70kg body takes before it is
+	// destroyed.
+	Health int
+
+	// Vital is true if destroying the part kills the body.
+	Vital bool
+}
+
+// Plan is the definition of the parts which make up a kind of body.
+type Plan struct {
+	Name  string
+	Parts []PartDef
+
+	// Blood is the amount of blood a 70kg body of the plan holds. Bodies
+	// with no blood don't bleed, and can only be killed by destroying a
+	// vital part.
+	Blood int
+}
+
+// Body is the state of a single creature's body.
+type Body struct {
+	Plan *Plan
+
+	// Damage holds the damage taken by each part of the plan.
+	Damage []int
+
+	// Health holds the damage each part of the plan may take before it is
+	// destroyed.
+	Health []int
+
+	Blood    int
+	MaxBlood int
+
+	// Bleeding is the amount of blood lost each time Bleed is called.
+	Bleeding int
+}
+
+// scale returns the factor part health and blood are scaled by for a body
+// of the given size, in kg.
+func scale(size float64) float64 {
+	return math.Sqrt(size / 70.0)
+}
+
+// New returns a new, unharmed body of the given plan and size in kg.
+func New(plan *Plan, size float64) *Body {
+	f := scale(size)
+	b := &Body{
+		Plan:   plan,
+		Damage: make([]int, len(plan.Parts)),
+		Health: make([]int, len(plan.Parts)),
+	}
+	for i, p := range plan.Parts {
+		b.Health[i] = scaled(p.Health, f)
+	}
+	if plan.Blood > 0 {
+		b.MaxBlood = scaled(plan.Blood, f)
+		b.Blood = b.MaxBlood
+	}
+	return b
+}
+
+// scaled returns v scaled by f, but never less than 1.
+func scaled(v int, f float64) int {
+	n := int(math.Round(float64(v) * f))
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// PartName returns the name of the part at the given index.
+func (b *Body) PartName(part int) string {
+	return b.Plan.Parts[part].Name
+}
+
+// Destroyed returns true if the part at the given index has been destroyed.
+func (b *Body) Destroyed(part int) bool {
+	return b.Damage[part] >= b.Health[part]
+}
+
+// Target chooses a random part which hasn't been destroyed to be hit by an
+// attack, weighted by the size of each part.
+func (b *Body) Target(r *rand.Rand) int {
+	total := 0
+	for i, p := range b.Plan.Parts {
+		if !b.Destroyed(i) {
+			total += p.Size
+		}
+	}
+	if total <= 0 {
+		return 0
+	}
+	n := r.Intn(total)
+	for i, p := range b.Plan.Parts {
+		if b.Destroyed(i) {
+			continue
+		}
+		if n < p.Size {
+			return i
+		}
+		n -= p.Size
+	}
+	return 0
+}
+
+// Wound deals damage to the part at the given index, returning true if the
+// wound destroyed the part.
+//
+// Bodies with blood start bleeding in proportion to the damage dealt.
+func (b *Body) Wound(part, damage int) bool {
+	if damage <= 0 || b.Destroyed(part) {
+		return false
+	}
+	if b.MaxBlood > 0 {
+		b.Bleeding += (damage + 1) / 2
+	}
+	return b.Hurt(part, damage)
+}
+
+// Hurt deals damage to the part at the given index without causing any
+// bleeding, returning true if the damage destroyed the part.
+func (b *Body) Hurt(part, damage int) bool {
+	if damage <= 0 || b.Destroyed(part) {
+		return false
+	}
+	b.Damage[part] += damage
+	if b.Damage[part] > b.Health[part] {
+		b.Damage[part] = b.Health[part]
+	}
+	return b.Destroyed(part)
+}
+
+// Core returns the index of the vital part with the most health; the part
+// which suffers from things that harm the whole body, such as starvation.
+func (b *Body) Core() int {
+	core := 0
+	for i, p := range b.Plan.Parts {
+		if p.Vital && (!b.Plan.Parts[core].Vital || b.Health[i] > b.Health[core]) {
+			core = i
+		}
+	}
+	return core
+}
+
+// Heal heals the given amount of damage from the most damaged part which
+// hasn't been destroyed, and replaces the same amount of lost blood.
+//
+// Destroyed parts never heal.
+func (b *Body) Heal(amount int) {
+	part := -1
+	for i := range b.Plan.Parts {
+		if b.Damage[i] > 0 && !b.Destroyed(i) && (part < 0 || b.Damage[i] > b.Damage[part]) {
+			part = i
+		}
+	}
+	if part >= 0 {
+		b.Damage[part] -= amount
+		if b.Damage[part] < 0 {
+			b.Damage[part] = 0
+		}
+	}
+	b.Blood += amount
+	if b.Blood > b.MaxBlood {
+		b.Blood = b.MaxBlood
+	}
+}
+
+// Bleed removes the blood lost to bleeding from the body, returning the
+// amount lost.
+//
+// Each call also lets the bleeding clot a little.
+func (b *Body) Bleed() int {
+	if b.Bleeding <= 0 {
+		return 0
+	}
+	lost := b.Bleeding
+	if lost > b.Blood {
+		lost = b.Blood
+	}
+	b.Blood -= lost
+	b.Bleeding--
+	return lost
+}
+
+// Dead returns true if a vital part of the body has been destroyed, or the
+// body has lost all of its blood.
+func (b *Body) Dead() bool {
+	for i, p := range b.Plan.Parts {
+		if p.Vital && b.Destroyed(i) {
+			return true
+		}
+	}
+	return b.MaxBlood > 0 && b.Blood <= 0
+}
+
+// Condition returns how healthy the body is, from 0.0 (dead) to 1.0
+// (unharmed).
+//
+// This is the lower of the health of the most damaged vital part and the
+// fraction of blood remaining.
+func (b *Body) Condition() float64 {
+	c := 1.0
+	for i, p := range b.Plan.Parts {
+		if !p.Vital {
+			continue
+		}
+		if h := 1.0 - float64(b.Damage[i])/float64(b.Health[i]); h < c {
+			c = h
+		}
+	}
+	if b.MaxBlood > 0 {
+		if h := float64(b.Blood) / float64(b.MaxBlood); h < c {
+			c = h
+		}
+	}
+	if c < 0 {
+		return 0
+	}
+	return c
+}
@@ -0,0 +1,62 @@
+package body
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBody(t *testing.T) {
+	t.Parallel()
+	plans := DefaultPlans()
+	t.Run("scale", func(t *testing.T) {
+		t.Parallel()
+		big := New(&plans[Quadruped], 70)
+		small := New(&plans[Quadruped], 2)
+		assert.Equal(t, plans[Quadruped].Parts[0].Health, big.Health[0])
+		assert.Less(t, small.Health[0], big.Health[0])
+		assert.GreaterOrEqual(t, small.Health[4], 1)
+	})
+	t.Run("vital", func(t *testing.T) {
+		t.Parallel()
+		b := New(&plans[Humanoid], 70)
+		assert.True(t, b.Wound(2, 100))
+		assert.True(t, b.Destroyed(2))
+		assert.False(t, b.Dead())
+		assert.True(t, b.Wound(0, 100))
+		assert.True(t, b.Dead())
+		assert.Zero(t, b.Condition())
+	})
+	t.Run("bleed", func(t *testing.T) {
+		t.Parallel()
+		b := New(&plans[Humanoid], 70)
+		b.Wound(1, 10)
+		require.Equal(t, 5, b.Bleeding)
+		total := 0
+		for b.Bleeding > 0 {
+			total += b.Bleed()
+		}
+		assert.Equal(t, 5+4+3+2+1, total)
+		assert.Equal(t, b.MaxBlood-total, b.Blood)
+		assert.False(t, b.Dead())
+	})
+	t.Run("bloodless", func(t *testing.T) {
+		t.Parallel()
+		b := New(&plans[Dummy], 70)
+		b.Wound(1, 10)
+		assert.Zero(t, b.Bleeding)
+		assert.Zero(t, b.Bleed())
+		assert.False(t, b.Dead())
+	})
+	t.Run("target", func(t *testing.T) {
+		t.Parallel()
+		b := New(&plans[Dummy], 70)
+		b.Wound(1, 1000)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, 0, b.Target(r))
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package body
+
+const (
+	Humanoid PlanID = iota
+	Quadruped
+	Arachnid
+	Winged
+	Dummy
+)
+
+// DefaultPlans returns the default set of body plans.
+//
+// The plans are ordered to match the PlanID constants.
+func DefaultPlans() []Plan {
+	return []Plan{
+		{
+			Name: "humanoid",
+			Parts: []PartDef{
+				{Name: "head", Size: 10, Health: 12, Vital: true},
+				{Name: "torso", Size: 36, Health: 30, Vital: true},
+				{Name: "left arm", Size: 12, Health: 15},
+				{Name: "right arm", Size: 12, Health: 15},
+				{Name: "left leg", Size: 15, Health: 18},
+				{Name: "right leg", Size: 15, Health: 18},
+			},
+			Blood: 50,
+		},
+		{
+			Name: "quadruped",
+			Parts: []PartDef{
+				{Name: "head", Size: 12, Health: 12, Vital: true},
+				{Name: "body", Size: 44, Health: 32, Vital: true},
+				{Name: "front legs", Size: 16, Health: 18},
+				{Name: "hind legs", Size: 16, Health: 18},
+				{Name: "tail", Size: 4, Health: 6},
+			},
+			Blood: 50,
+		},
+		{
+			Name: "arachnid",
+			Parts: []PartDef{
+				{Name: "head", Size: 15, Health: 12, Vital: true},
+				{Name: "abdomen", Size: 45, Health: 24, Vital: true},
+				{Name: "legs", Size: 40, Health: 20},
+			},
+			Blood: 30,
+		},
+		{
+			Name: "winged",
+			Parts: []PartDef{
+				{Name: "head", Size: 15, Health: 10, Vital: true},
+				{Name: "body", Size: 35, Health: 22, Vital: true},
+				{Name: "left wing", Size: 25, Health: 10},
+				{Name: "right wing", Size: 25, Health: 10},
+			},
+			Blood: 40,
+		},
+		{
+			Name: "dummy",
+			Parts: []PartDef{
+				{Name: "head", Size: 20, Health: 30, Vital: true},
+				{Name: "body", Size: 80, Health: 60, Vital: true},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package chunk
 
 import (
+	"github.com/tvarney/grogue/pkg/game/item"
 	"github.com/tvarney/grogue/pkg/game/tile"
 )
 
@@ -29,6 +30,30 @@ const (
 // Chunk is a chunk of the game world.
 type Chunk struct {
 	Tiles [Width * Height * Length]tile.State
+
+	// Memory holds the remembered appearance of the tiles in the chunk.
+	//
+	// Each z-level is allocated the first time a tile on that level is
+	// remembered, as most levels of most chunks are never seen.
+	Memory [Height]*[LayerSize]tile.State
+
+	// Sky holds the lowest z-level of each (x,y) column which is open to the
+	// sky. This is only updated by calling UpdateSky.
+	Sky [LayerSize]uint8
+
+	// Items holds the items lying in the chunk, keyed by tile index.
+	//
+	// Tiles with no items have no entry in the map.
+	Items map[int]*item.Inventory
+
+	// Spawns holds the positions chosen by the generator for the creatures
+	// which live in the chunk when it is first loaded.
+	Spawns []Spawn
+}
+
+// Spawn is the (x,y,z) position of a creature placed by the generator.
+type Spawn struct {
+	X, Y, Z int
 }
 
 // New returns a new Chunk instance.
@@ -46,3 +71,96 @@ func New() *Chunk {
 func (c *Chunk) Get(x, y, z int) *tile.State {
 	return &(c.Tiles[(z*LayerSize)+(y*Width)+x])
 }
+
+// Remember records the current appearance of the tile at (x,y,z).
+//
+// The stored copy has the tile.Remembered flag set, which distinguishes it
+// from a tile which has never been seen.
+func (c *Chunk) Remember(x, y, z int) {
+	layer := c.Memory[z]
+	if layer == nil {
+		layer = &[LayerSize]tile.State{}
+		c.Memory[z] = layer
+	}
+	m := &(layer[(y*Width)+x])
+	*m = *c.Get(x, y, z)
+	m.Flags |= tile.Remembered
+}
+
+// Recall returns the remembered appearance of the tile at (x,y,z).
+//
+// If the tile has never been seen, this returns nil. Like Get, this function
+// does no bounds checking.
+func (c *Chunk) Recall(x, y, z int) *tile.State {
+	layer := c.Memory[z]
+	if layer == nil {
+		return nil
+	}
+	m := &(layer[(y*Width)+x])
+	if m.Flags&tile.Remembered == 0 {
+		return nil
+	}
+	return m
+}
+
+// UpdateSky recalculates the lowest z-level of each column which is open to
+// the sky.
+//
+// This should be called whenever the blocks or floors of the chunk change.
+func (c *Chunk) UpdateSky() {
+	for i := 0; i < LayerSize; i++ {
+		c.Sky[i] = 0
+		for z := Height - 1; z >= 0; z-- {
+			t := &(c.Tiles[(z*LayerSize)+i])
+			if t.Block.Definition != tile.BlockEmpty || t.Floor.Definition != tile.FloorEmpty {
+				c.Sky[i] = uint8(z)
+				break
+			}
+		}
+	}
+}
+
+// OpenSky returns true if the tile at (x,y,z) is open to the sky.
+func (c *Chunk) OpenSky(x, y, z int) bool {
+	return z >= int(c.Sky[(y*Width)+x])
+}
+
+// ItemsAt returns the items lying on the tile at (x,y,z).
+//
+// If there are no items on the tile, this returns nil.
+func (c *Chunk) ItemsAt(x, y, z int) *item.Inventory {
+	return c.Items[(z*LayerSize)+(y*Width)+x]
+}
+
+// DropItem places items on the tile at (x,y,z), stacking them with any
+// matching items already there.
+func (c *Chunk) DropItem(x, y, z int, it item.Item) {
+	if it.Count <= 0 {
+		return
+	}
+	if c.Items == nil {
+		c.Items = make(map[int]*item.Inventory)
+	}
+	idx := (z * LayerSize) + (y * Width) + x
+	inv := c.Items[idx]
+	if inv == nil {
+		inv = &item.Inventory{}
+		c.Items[idx] = inv
+	}
+	inv.Add(it)
+}
+
+// TakeItem removes up to count items from the stack at index n of the items
+// on the tile at (x,y,z), returning the removed items.
+func (c *Chunk) TakeItem(x, y, z, n, count int) item.Item {
+	idx := (z * LayerSize) + (y * Width) + x
+	inv := c.Items[idx]
+	if inv == nil {
+		return item.Item{}
+	}
+	it := inv.Remove(n, count)
+	if inv.Len() == 0 {
+		delete(c.Items, idx)
+	}
+	return it
+}
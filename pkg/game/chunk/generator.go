@@ -7,12 +7,19 @@ import (
 	"math/rand"
 
 	perlin "github.com/aquilax/go-perlin"
+	"github.com/tvarney/grogue/pkg/game/item"
 	"github.com/tvarney/grogue/pkg/game/material"
 	"github.com/tvarney/grogue/pkg/game/tile"
 	"github.com/tvarney/grogue/pkg/simplehash"
 )
 
-const CaveOffset = 5
+const (
+	// CaveOffset is the lowest z-level caves are carved out of.
+	CaveOffset = 5
+
+	// SurfaceLevel is the z-level of the surface of the world.
+	SurfaceLevel = 33
+)
 
 type CaveParams struct {
 	Threshold  float64
@@ -62,6 +69,7 @@ type Generator struct {
 	Water   material.ID
 	Stone   []material.ID
 	Soil    []material.ID
+	Gem     []material.ID
 
 	rand       *rand.Rand
 	surface    *perlin.Perlin
@@ -102,6 +110,9 @@ func NewGenerator(seed int64, mats []*material.Material) *Generator {
 		case material.Soil:
 			log.Printf("Using material.ID(%d) as a soil", i+3)
 			g.Soil = append(g.Soil, material.ID(i+3))
+		case material.Gem:
+			log.Printf("Using material.ID(%d) as a gem", i+3)
+			g.Gem = append(g.Gem, material.ID(i+3))
 		}
 	}
 
@@ -170,6 +181,7 @@ func (g *Generator) Flat(cx, cy int64) *Chunk {
 		c.Tiles[idx].Random = uint32(hash.AddUint16(idx))
 	}
 
+	c.UpdateSky()
 	return c
 }
 
@@ -188,7 +200,7 @@ func (g *Generator) Generate(cx, cy int64) *Chunk {
 			case n < 0.35:
 				// Carve out for water
 				// Remove surface floor, clear flags
-				t := chunk.Get(x, y, 33)
+				t := chunk.Get(x, y, SurfaceLevel)
 				t.Floor = tile.Part{
 					Definition: tile.FloorEmpty,
 					Material:   g.Air,
@@ -196,7 +208,7 @@ func (g *Generator) Generate(cx, cy int64) *Chunk {
 				t.Flags = 0
 
 				// Remove block from below, add liquid
-				t = chunk.Get(x, y, 32)
+				t = chunk.Get(x, y, SurfaceLevel-1)
 				t.Block = tile.Part{
 					Definition: tile.BlockEmpty,
 					Material:   g.Air,
@@ -205,7 +217,7 @@ func (g *Generator) Generate(cx, cy int64) *Chunk {
 				t.LiquidMat = g.Water
 			case n < 0.37:
 				// Remove grass
-				chunk.Get(x, y, 33).Flags &= tile.StateFlags(bits.Reverse16(uint16(tile.HasGrass)))
+				chunk.Get(x, y, SurfaceLevel).Flags &= tile.StateFlags(bits.Reverse16(uint16(tile.HasGrass)))
 			}
 		}
 	}
@@ -232,5 +244,134 @@ func (g *Generator) Generate(cx, cy int64) *Chunk {
 		}
 	}
 
+	g.placeStairs(chunk, cx, cy)
+	g.scatterItems(chunk, cx, cy)
+
+	chunk.UpdateSky()
+	g.placeSpawns(chunk, cx, cy)
 	return chunk
 }
+
+// placeStairs digs a staircase from the surface down to the lowest cave level.
+//
+// The position of the staircase is chosen pseudo-randomly from the chunk
+// coordinates, avoiding any surface water. If no dry position is found, no
+// staircase is placed.
+func (g *Generator) placeStairs(c *Chunk, cx, cy int64) {
+	hash := simplehash.Initial32.AddInt64(cx).AddInt64(cy).AddUint8('S')
+	for try := uint8(0); try < 8; try++ {
+		hash = hash.AddUint8(try)
+		x := int(uint32(hash) % Width)
+		y := int((uint32(hash) >> 16) % Length)
+		if c.Get(x, y, SurfaceLevel-1).Liquid > 0 {
+			continue
+		}
+
+		for z := CaveOffset; z <= SurfaceLevel; z++ {
+			t := c.Get(x, y, z)
+			mat := t.Block.Material
+			if t.Block.Definition == tile.BlockEmpty {
+				mat = t.Floor.Material
+			}
+			if mat == g.Air {
+				mat = g.Stone[0]
+			}
+
+			def := tile.BlockStairsUpDown
+			switch z {
+			case CaveOffset:
+				def = tile.BlockStairsUp
+			case SurfaceLevel:
+				def = tile.BlockStairsDown
+			}
+			t.Block = tile.Part{Definition: def, Material: mat}
+			t.Flags = 0
+		}
+		return
+	}
+}
+
+// The number of creatures placed on the surface and in the caves of each
+// generated chunk, and the number of positions tried for each creature
+// before giving up.
+const (
+	SurfaceSpawns = 3
+	CaveSpawns    = 3
+	SpawnTries    = 20
+)
+
+// placeSpawns chooses the positions of the creatures living on the surface
+// and in the caves of the chunk.
+//
+// Positions are chosen pseudo-randomly from the chunk coordinates, on dry
+// floors open to the sky for the surface and below it for the caves. The
+// chunk's sky must be up to date.
+func (g *Generator) placeSpawns(c *Chunk, cx, cy int64) {
+	hash := simplehash.Initial32.AddInt64(cx).AddInt64(cy).AddUint8('C')
+	for _, cave := range []bool{false, true} {
+		count := SurfaceSpawns
+		if cave {
+			count = CaveSpawns
+		}
+		for try := 0; try < count*SpawnTries && count > 0; try++ {
+			hash = hash.AddUint16(uint16(try))
+			x := int(uint32(hash) % Width)
+			y := int((uint32(hash) >> 8) % Length)
+			z := SurfaceLevel
+			if cave {
+				z = CaveOffset + int((uint32(hash)>>16)%(SurfaceLevel-CaveOffset))
+			}
+			t := c.Get(x, y, z)
+			if t.Block.Definition != tile.BlockEmpty || t.Floor.Definition == tile.FloorEmpty || t.Liquid > 0 {
+				continue
+			}
+			if c.OpenSky(x, y, z) == cave || c.spawnAt(x, y, z) {
+				continue
+			}
+			c.Spawns = append(c.Spawns, Spawn{X: x, Y: y, Z: z})
+			count--
+		}
+	}
+}
+
+// spawnAt returns true if a creature is already placed at (x,y,z).
+func (c *Chunk) spawnAt(x, y, z int) bool {
+	for _, s := range c.Spawns {
+		if s == (Spawn{X: x, Y: y, Z: z}) {
+			return true
+		}
+	}
+	return false
+}
+
+// scatterItems places loose rocks and boulders on the surface and cave floors
+// of the chunk, along with the occasional gem in the caves.
+//
+// Items are placed pseudo-randomly from the chunk coordinates, and take the
+// material of the floor they lie on.
+func (g *Generator) scatterItems(c *Chunk, cx, cy int64) {
+	hash := simplehash.Initial32.AddInt64(cx).AddInt64(cy).AddUint8('I')
+	for z := CaveOffset; z <= SurfaceLevel; z++ {
+		for y := 0; y < Length; y++ {
+			for x := 0; x < Width; x++ {
+				t := c.Get(x, y, z)
+				if t.Block.Definition != tile.BlockEmpty || t.Floor.Definition == tile.FloorEmpty || t.Liquid > 0 {
+					continue
+				}
+				roll := uint32(hash.AddUint16(uint16((z * LayerSize) + (y * Width) + x)))
+				mat := t.Floor.Material
+				switch n := roll % 1000; {
+				case n < 4 && z < SurfaceLevel && len(g.Gem) > 0:
+					c.DropItem(x, y, z, item.New(item.Gem, g.Gem[int(roll>>16)%len(g.Gem)]))
+				case n < 12 && z < SurfaceLevel:
+					c.DropItem(x, y, z, item.New(item.Boulder, mat))
+				case n < 30:
+					if g.Materials[mat].Type == material.Soil {
+						mat = g.Stone[0]
+					}
+					c.DropItem(x, y, z, item.Item{Kind: item.Rock, Material: mat, Count: 1 + int(roll>>16)%3})
+				}
+			}
+		}
+	}
+}
@@ -46,9 +46,12 @@ const (
 	count
 )
 
-// Value returns the general color value set for the color.
+// Value returns the value of the color in the default palette.
+//
+// Drivers should resolve colors through the palette chosen by the player
+// instead.
 func (c Enum) Value() uint32 {
-	return values[c]
+	return defaults[c]
 }
 
 // Name returns the name of the color.
@@ -56,21 +59,41 @@ func (c Enum) Name() string {
 	return names[c]
 }
 
-// SetValue sets the value the color reports.
-func (c Enum) SetValue(v uint32) {
-	values[c] = 0xFFFFFF & v
+// Desaturate returns the 24-bit color value v with its saturation reduced.
+//
+// The amount is the fraction of the way to move each channel towards the
+// luminance of the color; 0.0 leaves the color unchanged, while 1.0 results in
+// a gray of the same brightness.
+func Desaturate(v uint32, amount float64) uint32 {
+	r, g, b := split(v)
+	lum := 0.299*r + 0.587*g + 0.114*b
+	return join(r+(lum-r)*amount, g+(lum-g)*amount, b+(lum-b)*amount)
+}
+
+// Scale returns the 24-bit color value v with each channel multiplied by f.
+//
+// Channels are clamped to the valid range of [0, 255].
+func Scale(v uint32, f float64) uint32 {
+	r, g, b := split(v)
+	return join(r*f, g*f, b*f)
+}
+
+func split(v uint32) (float64, float64, float64) {
+	return float64((v >> 16) & 0xFF), float64((v >> 8) & 0xFF), float64(v & 0xFF)
 }
 
-// Reset resets the value of the color to the default.
-func (c Enum) Reset() {
-	values[c] = defaults[c]
+func join(r, g, b float64) uint32 {
+	return clamp(r)<<16 | clamp(g)<<8 | clamp(b)
 }
 
-// ResetAll resets all colors to their default value.
-func ResetAll() {
-	for c := Enum(0); c < count; c++ {
-		values[c] = defaults[c]
+func clamp(c float64) uint32 {
+	if c <= 0.0 {
+		return 0
+	}
+	if c >= 255.0 {
+		return 255
 	}
+	return uint32(c + 0.5)
 }
 
 var (
@@ -82,15 +105,7 @@ var (
 		"dark brown", "brown", "bright brown", "dark pink", "pink", "bright pink",
 		"dark orange", "orange", "bright orange",
 	}
-	values = [count]uint32{
-		0x000000, 0x333333, 0x666666, 0x999999, 0xCCCCCC, 0xFFFFFF,
-		0x220000, 0x880000, 0xff0000, 0x002200, 0x008800, 0x00FF00,
-		0x000022, 0x000088, 0x0000ff, 0x222200, 0x888800, 0xFFFF00,
-		0x220022, 0x880088, 0xff00ff, 0x002222, 0x008888, 0x00FFFF,
-		0x221100, 0x551F00, 0x883300, 0x8E1592, 0xCA56CB, 0xFF88FF,
-		0xCC3311, 0xCD5421, 0xFF7711,
-	}
-	defaults = [count]uint32{
+	defaults = Palette{
 		0x000000, 0x333333, 0x666666, 0x999999, 0xCCCCCC, 0xFFFFFF,
 		0x220000, 0x880000, 0xff0000, 0x002200, 0x008800, 0x00FF00,
 		0x000022, 0x000088, 0x0000ff, 0x222200, 0x888800, 0xFFFF00,
@@ -1,6 +1,8 @@
 package color
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,7 +13,6 @@ func TestColor(t *testing.T) {
 	t.Parallel()
 	t.Run("values", func(t *testing.T) {
 		t.Parallel()
-		require.Len(t, values, int(count))
 		// Start at 1, because 0 is black and should be 0x000000 anyways
 		for c := Enum(1); c < count; c++ {
 			assert.NotZero(t, c.Value(), "color.Enum(%d)", c)
@@ -24,11 +25,102 @@ func TestColor(t *testing.T) {
 			assert.NotZero(t, c.Name(), "color.Enum(%d)", c)
 		}
 	})
-	t.Run("defaults", func(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
 		t.Parallel()
-		require.Len(t, defaults, int(count))
-		for c := Enum(1); c < count; c++ {
-			assert.NotZero(t, defaults[c], "color.Enum(%d)", c)
+		for c := Enum(0); c < count; c++ {
+			parsed, ok := ParseEnum(c.Name())
+			assert.True(t, ok)
+			assert.Equal(t, c, parsed)
+		}
+		_, ok := ParseEnum("octarine")
+		assert.False(t, ok)
+	})
+}
+
+func TestPalette(t *testing.T) {
+	t.Parallel()
+	t.Run("builtin", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range BuiltinPalettes {
+			p, ok := BuiltinPalette(name)
+			require.True(t, ok, name)
+			for c := Enum(1); c < count; c++ {
+				assert.NotZero(t, p.Value(c), "%s: %s", name, c.Name())
+			}
+		}
+		_, ok := BuiltinPalette("sepia")
+		assert.False(t, ok)
+
+		p := DefaultPalette()
+		assert.Equal(t, Red.Value(), p.Value(Red))
+	})
+	t.Run("independent", func(t *testing.T) {
+		t.Parallel()
+		p := DefaultPalette()
+		p.Set(Red, 0x123456)
+		assert.Equal(t, uint32(0x123456), p.Value(Red))
+		assert.Equal(t, uint32(0x880000), Red.Value())
+		def := DefaultPalette()
+		assert.Equal(t, uint32(0x880000), def.Value(Red))
+	})
+	t.Run("load", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "theme.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"base": "high-contrast", "colors": {"dark red": "#660000"}}`), 0o644))
+		p, err := LoadPalette(path)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0x660000), p.Value(DarkRed))
+		hc := HighContrastPalette()
+		assert.Equal(t, hc.Value(Red), p.Value(Red))
+
+		for _, data := range []string{
+			`{"base": "sepia"}`,
+			`{"colors": {"octarine": "#000000"}}`,
+			`{"colors": {"red": "#FF00"}}`,
+			`{"colors": {"red": "crimson"}}`,
+			`{`,
+		} {
+			require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+			_, err := LoadPalette(path)
+			assert.Error(t, err, data)
 		}
+
+		_, err = LoadPalette(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
 	})
 }
+
+func TestDesaturate(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, uint32(0xFF0000), Desaturate(0xFF0000, 0.0))
+	assert.Equal(t, uint32(0x4C4C4C), Desaturate(0xFF0000, 1.0))
+	assert.Equal(t, uint32(0x999999), Desaturate(0x999999, 0.5))
+}
+
+func TestScale(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, uint32(0x804020), Scale(0xFF8040, 0.5))
+	assert.Equal(t, uint32(0xFFFFFF), Scale(0x999999, 2.0))
+	assert.Equal(t, uint32(0x000000), Scale(0xFFFFFF, 0.0))
+}
+
+func TestXterm256(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, uint8(16), Xterm256(0x000000))
+	assert.Equal(t, uint8(196), Xterm256(0xFF0000))
+	assert.Equal(t, uint8(67), Xterm256(0x5F87AF))
+	assert.Equal(t, uint8(244), Xterm256(0x808080))
+	assert.Equal(t, uint8(231), Xterm256(0xFFFFFF))
+	for i := 16; i < 256; i++ {
+		assert.Equal(t, XtermValue(uint8(i)), XtermValue(Xterm256(XtermValue(uint8(i)))), "index %d", i)
+	}
+}
+
+func TestANSI16(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, uint8(0), ANSI16(0x220000))
+	assert.Equal(t, uint8(1), ANSI16(0x880000))
+	assert.Equal(t, uint8(9), ANSI16(0xFF0000))
+	assert.Equal(t, uint8(8), ANSI16(0x999999))
+	assert.Equal(t, uint8(15), ANSI16(0xFFFFFF))
+}
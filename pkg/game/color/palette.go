@@ -0,0 +1,160 @@
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Palette holds the 24-bit value of every color.
+//
+// Drivers resolve colors through the palette chosen by the player, so that
+// the same game data can be drawn with different themes.
+type Palette [count]uint32
+
+// The names of the built-in palettes.
+const (
+	PaletteDefault      = "default"
+	PaletteHighContrast = "high-contrast"
+	PaletteDeuteranopia = "deuteranopia"
+	PaletteProtanopia   = "protanopia"
+)
+
+// BuiltinPalettes are the names of the built-in palettes, in the order they
+// are offered to the player.
+var BuiltinPalettes = []string{PaletteDefault, PaletteHighContrast, PaletteDeuteranopia, PaletteProtanopia}
+
+// Value returns the value of the color in the palette.
+func (p *Palette) Value(c Enum) uint32 {
+	return p[c]
+}
+
+// Set sets the value of the color in the palette.
+func (p *Palette) Set(c Enum, v uint32) {
+	p[c] = 0xFFFFFF & v
+}
+
+// BuiltinPalette returns the built-in palette with the given name, or false
+// if there is no such palette.
+func BuiltinPalette(name string) (Palette, bool) {
+	switch name {
+	case PaletteDefault:
+		return DefaultPalette(), true
+	case PaletteHighContrast:
+		return HighContrastPalette(), true
+	case PaletteDeuteranopia:
+		return DeuteranopiaPalette(), true
+	case PaletteProtanopia:
+		return ProtanopiaPalette(), true
+	}
+	return Palette{}, false
+}
+
+// DefaultPalette returns the palette the game is designed with.
+func DefaultPalette() Palette {
+	return defaults
+}
+
+// HighContrastPalette returns a palette with brighter, more saturated
+// colors, so that dark colors stand out against a black background.
+func HighContrastPalette() Palette {
+	return Palette{
+		0x000000, 0x666666, 0x999999, 0xCCCCCC, 0xEEEEEE, 0xFFFFFF,
+		0x990000, 0xDD0000, 0xFF4444, 0x007700, 0x00BB00, 0x44FF44,
+		0x2222AA, 0x4444FF, 0x8888FF, 0x888800, 0xCCCC00, 0xFFFF44,
+		0x880088, 0xCC00CC, 0xFF44FF, 0x008888, 0x00CCCC, 0x44FFFF,
+		0x774400, 0xAA6600, 0xDD8833, 0xAA2288, 0xDD55CC, 0xFF99FF,
+		0xCC4400, 0xEE6600, 0xFF9933,
+	}
+}
+
+// DeuteranopiaPalette returns a palette for players with reduced green
+// sensitivity.
+//
+// Reds are shifted towards vermillion and greens towards a bluish green, so
+// that they differ in brightness and blue as well as in hue.
+func DeuteranopiaPalette() Palette {
+	return Palette{
+		0x000000, 0x333333, 0x666666, 0x999999, 0xCCCCCC, 0xFFFFFF,
+		0x2E1400, 0x99430A, 0xD55E00, 0x00261C, 0x009E73, 0x5CE0B8,
+		0x000F26, 0x0060A0, 0x3D9BFF, 0x2B2900, 0x9E9600, 0xF0E442,
+		0x221133, 0x7A55B5, 0xB48CFF, 0x0F2A38, 0x2E86BF, 0x8FD3F7,
+		0x221100, 0x553311, 0x886633, 0x7A3D60, 0xCC79A7, 0xF2B8D9,
+		0x996600, 0xE69F00, 0xFFC34D,
+	}
+}
+
+// ProtanopiaPalette returns a palette for players with reduced red
+// sensitivity.
+//
+// Reds appear darker to these players, so they are brightened and shifted
+// towards orange, while greens are shifted towards a bluish green.
+func ProtanopiaPalette() Palette {
+	return Palette{
+		0x000000, 0x333333, 0x666666, 0x999999, 0xCCCCCC, 0xFFFFFF,
+		0x4D2200, 0xCC5500, 0xFF7F2A, 0x00261C, 0x009E73, 0x5CE0B8,
+		0x000F26, 0x0060A0, 0x3D9BFF, 0x2B2900, 0x9E9600, 0xF0E442,
+		0x221133, 0x7A55B5, 0xB48CFF, 0x0F2A38, 0x2E86BF, 0x8FD3F7,
+		0x221100, 0x553311, 0x886633, 0x7A3D60, 0xCC79A7, 0xF2B8D9,
+		0x996600, 0xE69F00, 0xFFC34D,
+	}
+}
+
+// paletteFile is the format of palette files.
+type paletteFile struct {
+	// Base is the name of the built-in palette the file changes. If this is
+	// empty, the default palette is used.
+	Base string `json:"base"`
+
+	// Colors maps color names, as returned by Enum.Name, to "#RRGGBB"
+	// values.
+	Colors map[string]string `json:"colors"`
+}
+
+// LoadPalette reads a palette from the JSON file at the given path.
+//
+// The file names a built-in palette to start from and the colors to change,
+// as in:
+//
+//	{"base": "high-contrast", "colors": {"dark red": "#660000"}}
+func LoadPalette(path string) (Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Palette{}, err
+	}
+	var f paletteFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Palette{}, fmt.Errorf("invalid palette file %q: %w", path, err)
+	}
+	if f.Base == "" {
+		f.Base = PaletteDefault
+	}
+	p, ok := BuiltinPalette(f.Base)
+	if !ok {
+		return Palette{}, fmt.Errorf("invalid palette file %q: unknown base palette %q", path, f.Base)
+	}
+	for name, hex := range f.Colors {
+		c, ok := ParseEnum(name)
+		if !ok {
+			return Palette{}, fmt.Errorf("invalid palette file %q: unknown color %q", path, name)
+		}
+		v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+		if err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+			return Palette{}, fmt.Errorf("invalid palette file %q: invalid value %q for %s", path, hex, name)
+		}
+		p.Set(c, uint32(v))
+	}
+	return p, nil
+}
+
+// ParseEnum returns the color with the given name, as returned by Name.
+func ParseEnum(name string) (Enum, bool) {
+	for c := Enum(0); c < count; c++ {
+		if names[c] == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
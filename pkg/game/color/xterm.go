@@ -0,0 +1,90 @@
+package color
+
+// ansi holds the values of the 16 standard ANSI colors, as used by xterm for
+// the first 16 entries of its 256-color palette.
+var ansi = [16]uint32{
+	0x000000, 0x800000, 0x008000, 0x808000, 0x000080, 0x800080, 0x008080, 0xC0C0C0,
+	0x808080, 0xFF0000, 0x00FF00, 0xFFFF00, 0x0000FF, 0xFF00FF, 0x00FFFF, 0xFFFFFF,
+}
+
+// cubeLevels are the channel values of the 6x6x6 color cube of the xterm
+// 256-color palette, which starts at index 16.
+var cubeLevels = [6]uint32{0x00, 0x5F, 0x87, 0xAF, 0xD7, 0xFF}
+
+// ANSI16 returns the index of the standard ANSI color nearest to the 24-bit
+// color value v.
+func ANSI16(v uint32) uint8 {
+	best := 0
+	for i := range ansi {
+		if distance(v, ansi[i]) < distance(v, ansi[best]) {
+			best = i
+		}
+	}
+	return uint8(best)
+}
+
+// Xterm256 returns the index of the color of the xterm 256-color palette
+// nearest to the 24-bit color value v.
+//
+// Only the color cube and the gray ramp are considered, as the values of the
+// first 16 colors vary between terminals.
+func Xterm256(v uint32) uint8 {
+	r, g, b := (v>>16)&0xFF, (v>>8)&0xFF, v&0xFF
+	cube := 16 + 36*cubeIndex(r) + 6*cubeIndex(g) + cubeIndex(b)
+
+	// The gray ramp runs from 0x08 to 0xEE in steps of 10
+	avg := (r + g + b) / 3
+	gray := 23
+	if avg < 0x08 {
+		gray = 0
+	} else if avg < 0xEE {
+		gray = int(avg-0x08+5) / 10
+	}
+
+	if distance(v, XtermValue(uint8(232+gray))) < distance(v, XtermValue(uint8(cube))) {
+		return uint8(232 + gray)
+	}
+	return uint8(cube)
+}
+
+// XtermValue returns the 24-bit color value of the entry of the xterm
+// 256-color palette with the given index.
+func XtermValue(i uint8) uint32 {
+	switch {
+	case i < 16:
+		return ansi[i]
+	case i >= 232:
+		l := 0x08 + 10*uint32(i-232)
+		return l<<16 | l<<8 | l
+	}
+	i -= 16
+	return cubeLevels[i/36]<<16 | cubeLevels[(i/6)%6]<<8 | cubeLevels[i%6]
+}
+
+// cubeIndex returns the index of the color cube level nearest to the
+// channel value c.
+func cubeIndex(c uint32) int {
+	best := 0
+	for i, l := range cubeLevels {
+		if diff(c, l) < diff(c, cubeLevels[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// distance returns the squared distance between two 24-bit color values,
+// weighted by how sensitive the eye is to each channel.
+func distance(a, b uint32) uint32 {
+	dr := diff((a>>16)&0xFF, (b>>16)&0xFF)
+	dg := diff((a>>8)&0xFF, (b>>8)&0xFF)
+	db := diff(a&0xFF, b&0xFF)
+	return 3*dr*dr + 4*dg*dg + 2*db*db
+}
+
+func diff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
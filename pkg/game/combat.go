@@ -0,0 +1,273 @@
+package game
+
+import (
+	"math"
+
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/creature"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const (
+	// HitChance is the percent chance of a melee attack hitting.
+	HitChance = 80
+
+	// unarmedDamage is the base damage of the player's unarmed attacks.
+	unarmedDamage = 1
+)
+
+// WeaponDamage returns the damage of a weapon with the given base damage
+// made of the given material.
+//
+// Denser materials hit harder, and harder materials hold a better edge.
+func WeaponDamage(base int, mat *material.Material) float64 {
+	return float64(base) * (0.5 + mat.Density/5.0) * (0.5 + mat.Hardness/10.0)
+}
+
+// ArmorAbsorb returns the damage armor made of the given material absorbs
+// from each hit.
+func ArmorAbsorb(mat *material.Material) float64 {
+	return mat.Hardness/4.0 + mat.Density/8.0
+}
+
+// fighter is one side of a melee attack; either the player or a creature.
+type fighter struct {
+	creature *Creature
+}
+
+// player returns true if the fighter is the player.
+func (f fighter) player() bool {
+	return f.creature == nil
+}
+
+func (g *Game) fighterBody(f fighter) *body.Body {
+	if f.player() {
+		return g.Character.Body
+	}
+	return f.creature.Body
+}
+
+func (g *Game) fighterPos(f fighter) Coords {
+	if f.player() {
+		return g.Player
+	}
+	return f.creature.Pos
+}
+
+// fighterName returns how the fighter is referred to in messages.
+func (g *Game) fighterName(f fighter) string {
+	if f.player() {
+		return "you"
+	}
+	return "the " + g.SpeciesOf(f.creature).Name
+}
+
+// possessive returns the possessive form of the fighter's name.
+func (g *Game) possessive(f fighter) string {
+	if f.player() {
+		return "your"
+	}
+	return g.fighterName(f) + "'s"
+}
+
+// naturalWeapon returns the material natural attacks are made with.
+func (g *Game) naturalWeapon() *material.Material {
+	id, _ := g.materialOfType(material.Bone)
+	return g.Materials[id]
+}
+
+// PlayerAttack has the player attack the creature with their wielded weapon,
+// or with their fists if they aren't wielding anything.
+func (g *Game) PlayerAttack(cr *Creature) {
+	sp := g.SpeciesOf(cr)
+	if sp.Behavior == creature.Wander && sp.Attack.Damage > 0 {
+		cr.Hostile = true
+	}
+	g.attack(fighter{}, fighter{creature: cr})
+}
+
+// CreatureAttack has the creature attack whatever is at the target position.
+func (g *Game) CreatureAttack(cr *Creature, target Coords) {
+	if target == g.Player {
+		g.attack(fighter{creature: cr}, fighter{})
+		return
+	}
+	if o := g.CreatureAt(target); o != nil {
+		g.attack(fighter{creature: cr}, fighter{creature: o})
+	}
+}
+
+// attack resolves a single melee attack.
+func (g *Game) attack(att, def fighter) {
+	// Only tell the player about fights they are in or can see
+	shown := att.player() || def.player() || g.IsVisible(g.fighterPos(def))
+	sev := message.Info
+	if def.player() {
+		sev = message.Danger
+	}
+
+	verb, base, mat := "punch", unarmedDamage, g.naturalWeapon()
+	if att.player() {
+		if w := g.Equipment.Weapon; w != nil {
+			verb, base, mat = "hit", w.Kind.Info().Damage, g.Materials[w.Material]
+		}
+	} else {
+		a := g.SpeciesOf(att.creature).Attack
+		verb, base = a.Verb, a.Damage
+	}
+
+	chance, factor := HitChance, 1.0
+	if att.player() {
+		chance, factor = g.Character.HitChance(), g.Character.DamageFactor()
+	}
+	attName, defName := capitalize(g.fighterName(att)), g.fighterName(def)
+	if g.Rand.Intn(100) >= chance {
+		if shown {
+			g.Message(sev, "%s %s %s.", attName, missVerb(att), defName)
+		}
+		return
+	}
+
+	b := g.fighterBody(def)
+	part := b.Target(g.Rand)
+	damage := WeaponDamage(base, mat) * factor * (0.75 + g.Rand.Float64()*0.5)
+	var armor *item.Item
+	if def.player() {
+		if armor = g.Equipment.ArmorFor(b.PartName(part)); armor != nil {
+			damage -= ArmorAbsorb(g.Materials[armor.Material])
+		}
+	}
+	dmg := int(math.Round(damage))
+	if dmg <= 0 && armor == nil {
+		dmg = 1
+	}
+	if dmg <= 0 {
+		if shown {
+			g.Message(sev, "%s %s %s in the %s, but %s %s absorbs the blow.",
+				attName, verb, defName, b.PartName(part), g.possessive(def), armor.Kind)
+		}
+		return
+	}
+
+	bleeding := b.Bleeding > 0
+	destroyed := b.Wound(part, dmg)
+	if att.player() {
+		def.creature.Provoked = true
+	}
+	if shown {
+		g.Message(sev, "%s %s %s in the %s.", attName, verb, defName, b.PartName(part))
+		if destroyed && !b.Dead() {
+			g.Message(sev, "%s %s is mangled!", capitalize(g.possessive(def)), b.PartName(part))
+		}
+		if !bleeding && b.Bleeding > 0 && !b.Dead() {
+			g.Message(sev, "%s %s bleeding.", capitalize(defName), beVerb(def))
+		}
+	}
+	if b.Dead() {
+		if def.player() {
+			g.Die("killed by a " + g.SpeciesOf(att.creature).Name)
+			return
+		}
+		g.kill(def, false)
+	}
+}
+
+// missVerb returns the verb used when the fighter misses an attack.
+func missVerb(f fighter) string {
+	if f.player() {
+		return "miss"
+	}
+	return "misses"
+}
+
+// beVerb returns the form of "to be" used with the fighter's name.
+func beVerb(f fighter) string {
+	if f.player() {
+		return "are"
+	}
+	return "is"
+}
+
+// bleed applies the fighter's bleeding, killing them if they run out of
+// blood.
+func (g *Game) bleed(f fighter) {
+	b := g.fighterBody(f)
+	if b.Bleed() == 0 || !b.Dead() {
+		return
+	}
+	if f.player() {
+		g.Die("bled to death")
+		return
+	}
+	g.kill(f, true)
+}
+
+// kill handles the death of a creature.
+//
+// Creatures with blood leave a corpse and bones behind. Creatures which were
+// wounded by the player count as kills of the player.
+func (g *Game) kill(f fighter, bled bool) {
+	cr := f.creature
+	if cr.Provoked {
+		g.Character.Kills++
+	}
+	sp := g.SpeciesOf(cr)
+	if g.IsVisible(cr.Pos) {
+		switch {
+		case cr.Body.MaxBlood == 0:
+			g.Message(message.Good, "The %s is destroyed.", sp.Name)
+		case bled:
+			g.Message(message.Good, "The %s bleeds to death.", sp.Name)
+		default:
+			g.Message(message.Good, "The %s dies.", sp.Name)
+		}
+	}
+	if cr.Body.MaxBlood > 0 {
+		g.dropRemains(cr)
+	}
+	g.RemoveCreature(cr)
+}
+
+// dropRemains drops the corpse and bones of the creature where it stands.
+func (g *Game) dropRemains(cr *Creature) {
+	ch := g.Chunk(cr.Pos.Chunk)
+	if ch == nil {
+		return
+	}
+	sp := g.SpeciesOf(cr)
+	if id, ok := g.materialOfType(material.Flesh); ok {
+		ch.DropItem(cr.Pos.X, cr.Pos.Y, cr.Pos.Z, item.Item{
+			Kind: item.Corpse, Material: id, Count: 1,
+			Label: sp.Name, Volume: sp.Size * 0.8 / g.Materials[id].Density,
+		})
+	}
+	if id, ok := g.materialOfType(material.Bone); ok {
+		bones := int(sp.Size / 10)
+		if bones < 1 {
+			bones = 1
+		}
+		ch.DropItem(cr.Pos.X, cr.Pos.Y, cr.Pos.Z, item.Item{
+			Kind: item.Bone, Material: id, Count: bones, Label: sp.Name,
+		})
+	}
+}
+
+// materialOfType returns the first material of the given type.
+func (g *Game) materialOfType(t material.Type) (material.ID, bool) {
+	for i, m := range g.Materials {
+		if m.Type == t {
+			return material.ID(i), true
+		}
+	}
+	return 0, false
+}
+
+// capitalize returns s with its first letter in upper case.
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/item"
+)
+
+func TestWeaponDamage(t *testing.T) {
+	t.Parallel()
+	g := newTestGame(t)
+	const stone, iron, oak = 3, 5, 7
+	axe := item.Axe.Info().Damage
+	assert.Greater(t, WeaponDamage(axe, g.Materials[iron]), WeaponDamage(axe, g.Materials[stone]))
+	assert.Greater(t, WeaponDamage(axe, g.Materials[stone]), WeaponDamage(axe, g.Materials[oak]))
+	assert.Greater(t, ArmorAbsorb(g.Materials[iron]), ArmorAbsorb(g.Materials[oak]))
+}
+
+func TestCombat(t *testing.T) {
+	t.Parallel()
+	t.Run("dummy", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.Equipment.Weapon = &item.Item{Kind: item.Axe, Material: 5, Count: 1}
+		cr := g.AddCreature(speciesID(t, g, TrainingDummy), g.Player.Offset(1, 0, 0))
+		for i := 0; i < 100 && g.CreatureAt(cr.Pos) != nil; i++ {
+			g.PlayerAttack(cr)
+		}
+		assert.Nil(t, g.CreatureAt(cr.Pos))
+		assert.Nil(t, g.ItemsAt(cr.Pos), "bloodless creatures leave no corpse")
+	})
+	t.Run("corpse", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		cr := g.AddCreature(speciesID(t, g, "wolf"), g.Player.Offset(1, 0, 0))
+		for i := 0; i < 1000 && g.CreatureAt(cr.Pos) != nil; i++ {
+			g.PlayerAttack(cr)
+			g.bleed(fighter{creature: cr})
+		}
+		require.Nil(t, g.CreatureAt(cr.Pos))
+		items := g.ItemsAt(cr.Pos)
+		require.NotNil(t, items)
+		require.Equal(t, 2, items.Len())
+		assert.Equal(t, "wolf corpse", items.Items[0].Name(g.Materials))
+		assert.Equal(t, "4 wolf bones", items.Items[1].Name(g.Materials))
+	})
+	t.Run("player", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		cr := g.AddCreature(speciesID(t, g, "wolf"), g.Player.Offset(1, 0, 0))
+		for i := 0; i < 1000 && !g.Dead; i++ {
+			g.CreatureAttack(cr, g.Player)
+		}
+		assert.True(t, g.Dead)
+		assert.True(t, g.Character.Body.Dead())
+	})
+	t.Run("bleeding", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		cr := g.AddCreature(speciesID(t, g, "wolf"), g.Player.Offset(1, 0, 0))
+		for i := 0; i < 1000 && g.Character.Body.Bleeding == 0; i++ {
+			g.CreatureAttack(cr, g.Player)
+		}
+		require.Positive(t, g.Character.Body.Bleeding)
+		for i := 0; i < 1000 && cr.Body.Bleeding == 0; i++ {
+			g.PlayerAttack(cr)
+		}
+		require.Positive(t, cr.Body.Bleeding)
+
+		// Only the first wound causing bleeding is reported
+		g.CreatureAttack(cr, g.Player)
+		assert.Equal(t, 1, countMessages(g, "You are bleeding."))
+		assert.Equal(t, 1, countMessages(g, "The wolf is bleeding."))
+	})
+	t.Run("armor", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.Equipment.Worn = []item.Item{
+			{Kind: item.Helmet, Material: 5, Count: 1},
+			{Kind: item.Cuirass, Material: 5, Count: 1},
+		}
+		cr := g.AddCreature(speciesID(t, g, "rabbit"), g.Player.Offset(1, 0, 0))
+		for i := 0; i < 100; i++ {
+			g.CreatureAttack(cr, g.Player)
+		}
+		assert.Zero(t, g.Character.Body.Damage[0])
+		assert.Zero(t, g.Character.Body.Damage[1])
+	})
+}
+
+// countMessages returns the number of messages in the log with the given
+// text.
+func countMessages(g *Game, text string) int {
+	n := 0
+	for _, m := range g.Messages.All() {
+		if m.Text == text {
+			n += m.Count
+		}
+	}
+	return n
+}
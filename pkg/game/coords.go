@@ -1,5 +1,7 @@
 package game
 
+import "github.com/tvarney/grogue/pkg/game/chunk"
+
 // ChunkCoords is the (x,y) pair of coordinates identifying a chunk.
 type ChunkCoords struct {
 	X int
@@ -18,3 +20,37 @@ type Coords struct {
 
 	Chunk ChunkCoords
 }
+
+// GlobalCoords returns the Coords for the given world-space position.
+//
+// World-space (x,y) values are the tile offsets from the origin of chunk
+// (0,0); the chunk coordinates are derived from them.
+func GlobalCoords(x, y, z int) Coords {
+	cx, lx := divmod(x, chunk.Width)
+	cy, ly := divmod(y, chunk.Length)
+	return Coords{X: lx, Y: ly, Z: z, Chunk: ChunkCoords{X: cx, Y: cy}}
+}
+
+// Global returns the world-space (x,y) position of the coordinates.
+func (c Coords) Global() (int, int) {
+	return c.Chunk.X*chunk.Width + c.X, c.Chunk.Y*chunk.Length + c.Y
+}
+
+// Offset returns the coordinates moved by the given deltas.
+//
+// The resulting (x,y) values are normalized into the chunk they fall in,
+// updating the chunk coordinates as needed. The z value is not checked.
+func (c Coords) Offset(dx, dy, dz int) Coords {
+	x, y := c.Global()
+	return GlobalCoords(x+dx, y+dy, c.Z+dz)
+}
+
+// divmod returns the floored quotient and the non-negative remainder of a/b.
+func divmod(a, b int) (int, int) {
+	q, r := a/b, a%b
+	if r < 0 {
+		q--
+		r += b
+	}
+	return q, r
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/craft"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const CraftMenuID = "craft"
+
+// CraftTicks is the number of ticks crafting an item takes.
+const CraftTicks = 10 * TurnTicks
+
+// NearWorkshop returns true if the player is on or next to a workshop of one
+// of the given kinds.
+//
+// If no kinds are given, this always returns true.
+func (g *Game) NearWorkshop(kinds []item.Kind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			items := g.ItemsAt(g.Player.Offset(dx, dy, 0))
+			if items == nil {
+				continue
+			}
+			for i := range items.Items {
+				for _, k := range kinds {
+					if items.Items[i].Kind == k {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// CanCraft returns true if the player may craft the recipe where they stand.
+func (g *Game) CanCraft(r *craft.Recipe) bool {
+	if !g.NearWorkshop(r.Workshops) {
+		return false
+	}
+	_, err := r.Plan(&g.Inventory, g.Materials)
+	return err == nil
+}
+
+// Craft crafts the recipe from the items in the player's inventory.
+//
+// The crafted items are added to the player's inventory.
+func (g *Game) Craft(r *craft.Recipe) {
+	if !g.NearWorkshop(r.Workshops) {
+		g.Message(message.Info, "You need to be at a workshop to make a %s.", r.Name)
+		return
+	}
+	p, err := r.Plan(&g.Inventory, g.Materials)
+	switch err {
+	case nil:
+	case craft.ErrMissingTool:
+		g.Message(message.Info, "You don't have the tools to make a %s.", r.Name)
+		return
+	default:
+		g.Message(message.Info, "You don't have the materials to make a %s.", r.Name)
+		return
+	}
+	it := p.Apply(&g.Inventory)
+	g.Inventory.Add(it)
+	g.Message(message.Good, "You make %s.", it.Name(g.Materials))
+	g.EndTurn(CraftTicks)
+}
+
+// NewCraftMenu returns a new StaticMenu instance listing the crafting
+// recipes.
+//
+// Recipes the player can currently craft are highlighted; selecting a recipe
+// crafts it.
+func NewCraftMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    CraftMenuID,
+		Title: "Craft",
+	}
+	var refresh func(*Application)
+	refresh = func(app *Application) {
+		g := app.Game
+		m.Options = make([]string, len(g.Recipes))
+		m.Colors = make([]color.Enum, len(g.Recipes))
+		m.Actions = make([]func(*Application) RenderRequest, len(g.Recipes))
+		for i := range g.Recipes {
+			r := &g.Recipes[i]
+			m.Options[i] = fmt.Sprintf("%s (%s)", r.Name, r.Requirements())
+			m.Colors[i] = color.DarkGray
+			if g.CanCraft(r) {
+				m.Colors[i] = color.BrightGray
+			}
+			m.Actions[i] = func(app *Application) RenderRequest {
+				app.Game.Craft(r)
+				refresh(app)
+				return RenderFull
+			}
+		}
+		m.SetOption(m.Cursor)
+	}
+	m.OnStart = refresh
+	m.OnResume = refresh
+	return m
+}
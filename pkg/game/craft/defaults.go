@@ -0,0 +1,82 @@
+package craft
+
+import (
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+// DefaultRecipes returns the default set of recipes.
+func DefaultRecipes() []Recipe {
+	return []Recipe{
+		{
+			Name:   "stone hammer",
+			Inputs: []Input{{Kind: item.Rock, Type: material.Stone, Count: 2}},
+			Output: item.Hammer,
+		},
+		{
+			Name:   "stone axe",
+			Inputs: []Input{{Kind: item.Rock, Type: material.Stone, Count: 2}},
+			Tools:  []item.Kind{item.Hammer},
+			Output: item.Axe,
+		},
+		{
+			Name:   "stone pick",
+			Inputs: []Input{{Kind: item.Rock, Type: material.Stone, Count: 3}},
+			Tools:  []item.Kind{item.Hammer},
+			Output: item.Pick,
+		},
+		{
+			Name:   "stone anvil",
+			Inputs: []Input{{Kind: item.Boulder, Type: material.Stone, Count: 1}},
+			Tools:  []item.Kind{item.Hammer},
+			Output: item.Anvil,
+		},
+		{
+			Name:      "metal hammer",
+			Inputs:    []Input{{Kind: item.Bar, Type: material.Metal, Count: 1}},
+			Tools:     []item.Kind{item.Hammer},
+			Workshops: []item.Kind{item.Anvil},
+			Output:    item.Hammer,
+		},
+		{
+			Name: "metal axe",
+			Inputs: []Input{
+				{Kind: item.Bar, Type: material.Metal, Count: 1},
+				{Kind: item.Log, Type: material.Wood, Count: 1},
+			},
+			Tools:     []item.Kind{item.Hammer},
+			Workshops: []item.Kind{item.Anvil},
+			Output:    item.Axe,
+		},
+		{
+			Name: "metal pick",
+			Inputs: []Input{
+				{Kind: item.Bar, Type: material.Metal, Count: 2},
+				{Kind: item.Log, Type: material.Wood, Count: 1},
+			},
+			Tools:     []item.Kind{item.Hammer},
+			Workshops: []item.Kind{item.Anvil},
+			Output:    item.Pick,
+		},
+		{
+			Name:      "metal helmet",
+			Inputs:    []Input{{Kind: item.Bar, Type: material.Metal, Count: 1}},
+			Tools:     []item.Kind{item.Hammer},
+			Workshops: []item.Kind{item.Anvil},
+			Output:    item.Helmet,
+		},
+		{
+			Name:      "metal cuirass",
+			Inputs:    []Input{{Kind: item.Bar, Type: material.Metal, Count: 3}},
+			Tools:     []item.Kind{item.Hammer},
+			Workshops: []item.Kind{item.Anvil},
+			Output:    item.Cuirass,
+		},
+		{
+			Name:   "bone helmet",
+			Inputs: []Input{{Kind: item.Bone, Type: material.Bone, Count: 3}},
+			Tools:  []item.Kind{item.Axe},
+			Output: item.Helmet,
+		},
+	}
+}
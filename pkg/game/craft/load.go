@@ -0,0 +1,125 @@
+package craft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+// recipeFile is the format of a single recipe of a recipe file.
+type recipeFile struct {
+	Name   string `json:"name"`
+	Inputs []struct {
+		Kind  string `json:"kind"`
+		Type  string `json:"type"`
+		Count int    `json:"count"`
+	} `json:"inputs"`
+	Tools        []string `json:"tools"`
+	Workshops    []string `json:"workshops"`
+	Output       string   `json:"output"`
+	Count        int      `json:"count"`
+	MaterialFrom int      `json:"material_from"`
+}
+
+// LoadRecipes reads the list of recipes from the JSON file at the given
+// path.
+//
+// The file holds a list of recipes, with item kinds and material types given
+// by name, as in:
+//
+//	[{"name": "metal axe", "output": "axe",
+//	  "inputs": [{"kind": "bar", "type": "metal", "count": 1},
+//	             {"kind": "log", "type": "wood", "count": 1}],
+//	  "tools": ["hammer"], "workshops": ["anvil"], "material_from": 0}]
+//
+// The recipes of the file replace the default recipes. If the file doesn't
+// exist, the default recipes are returned without an error.
+func LoadRecipes(path string) ([]Recipe, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return DefaultRecipes(), nil
+		}
+		return DefaultRecipes(), err
+	}
+	var entries []recipeFile
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return DefaultRecipes(), fmt.Errorf("invalid recipe file %q: %w", path, err)
+	}
+	recipes := make([]Recipe, 0, len(entries))
+	for i := range entries {
+		r, err := entries[i].recipe()
+		if err != nil {
+			return DefaultRecipes(), fmt.Errorf("invalid recipe file %q: recipe %d: %w", path, i, err)
+		}
+		recipes = append(recipes, r)
+	}
+	return recipes, nil
+}
+
+// recipe returns the recipe defined by the entry.
+func (e *recipeFile) recipe() (Recipe, error) {
+	r := Recipe{Name: e.Name, Count: e.Count, MaterialFrom: e.MaterialFrom}
+	if r.Name == "" {
+		return r, fmt.Errorf("missing name")
+	}
+	if len(e.Inputs) == 0 {
+		return r, fmt.Errorf("%s: no inputs", e.Name)
+	}
+	if r.MaterialFrom < 0 || r.MaterialFrom >= len(e.Inputs) {
+		return r, fmt.Errorf("%s: invalid material_from %d", e.Name, r.MaterialFrom)
+	}
+
+	for _, in := range e.Inputs {
+		kind, err := parseKind(e.Name, in.Kind)
+		if err != nil {
+			return r, err
+		}
+		typ, ok := material.ParseType(in.Type)
+		if !ok {
+			return r, fmt.Errorf("%s: unknown material type %q", e.Name, in.Type)
+		}
+		if in.Count < 1 {
+			return r, fmt.Errorf("%s: invalid count %d", e.Name, in.Count)
+		}
+		r.Inputs = append(r.Inputs, Input{Kind: kind, Type: typ, Count: in.Count})
+	}
+	var err error
+	if r.Tools, err = parseKinds(e.Name, e.Tools); err != nil {
+		return r, err
+	}
+	if r.Workshops, err = parseKinds(e.Name, e.Workshops); err != nil {
+		return r, err
+	}
+	if r.Output, err = parseKind(e.Name, e.Output); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// parseKinds returns the item kinds with the given names.
+func parseKinds(recipe string, names []string) ([]item.Kind, error) {
+	var kinds []item.Kind
+	for _, name := range names {
+		k, err := parseKind(recipe, name)
+		if err != nil {
+			return nil, err
+		}
+		kinds = append(kinds, k)
+	}
+	return kinds, nil
+}
+
+// parseKind returns the item kind with the given name, or an error naming
+// the recipe if there is no such kind.
+func parseKind(recipe, name string) (item.Kind, error) {
+	k, ok := item.ParseKind(name)
+	if !ok {
+		return 0, fmt.Errorf("%s: unknown item kind %q", recipe, name)
+	}
+	return k, nil
+}
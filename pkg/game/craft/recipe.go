@@ -0,0 +1,198 @@
+package craft
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tvarney/grogue/pkg/cerr"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+const (
+	// ErrMissingInput is returned when an inventory doesn't hold the inputs
+	// of a recipe.
+	ErrMissingInput = cerr.Error("missing recipe inputs")
+
+	// ErrMissingTool is returned when an inventory doesn't hold the tools a
+	// recipe requires.
+	ErrMissingTool = cerr.Error("missing recipe tools")
+)
+
+// Input is one of the inputs of a recipe.
+//
+// Any item of the given kind made of a material of the given type may be used
+// as the input.
+type Input struct {
+	Kind  item.Kind
+	Type  material.Type
+	Count int
+}
+
+// Recipe defines how items are crafted from other items.
+type Recipe struct {
+	Name   string
+	Inputs []Input
+
+	// Tools are the kinds of tools which must be carried to use the recipe.
+	// Tools are not consumed by crafting.
+	Tools []item.Kind
+
+	// Workshops are the kinds of workshop the recipe may be crafted at. If
+	// this is empty, the recipe may be crafted anywhere.
+	Workshops []item.Kind
+
+	Output item.Kind
+	Count  int
+
+	// MaterialFrom is the index of the input the output takes its material
+	// from. The items used for that input are always of a single material,
+	// while the items used for the other inputs may be of any materials of
+	// their type.
+	MaterialFrom int
+}
+
+// Use is the number of items a plan takes from a stack of an inventory.
+type Use struct {
+	Index int
+	Count int
+}
+
+// Plan is the set of items a recipe uses from an inventory.
+type Plan struct {
+	Recipe   *Recipe
+	Uses     []Use
+	Material material.ID
+}
+
+// Plan selects the items of the inventory which would be used to craft the
+// recipe.
+//
+// Inputs are taken from the first stacks of the inventory which match them.
+// The MaterialFrom input is taken from the first material with enough
+// matching items, and the output is made of that material. This does not
+// check the workshop requirements of the recipe.
+func (r *Recipe) Plan(inv *item.Inventory, mats []*material.Material) (Plan, error) {
+	for _, tool := range r.Tools {
+		if !hasKind(inv, tool) {
+			return Plan{}, ErrMissingTool
+		}
+	}
+
+	p := Plan{Recipe: r}
+	used := make([]int, inv.Len())
+	for i, in := range r.Inputs {
+		match := func(it *item.Item) bool {
+			return it.Kind == in.Kind && mats[it.Material].Type == in.Type
+		}
+		if i == r.MaterialFrom {
+			m, ok := pickMaterial(inv, used, in.Count, match)
+			if !ok {
+				return Plan{}, ErrMissingInput
+			}
+			p.Material = m
+			match = func(it *item.Item) bool {
+				return it.Kind == in.Kind && it.Material == m
+			}
+		}
+
+		need := in.Count
+		for idx := range inv.Items {
+			if need == 0 {
+				break
+			}
+			it := &inv.Items[idx]
+			if !match(it) || used[idx] >= it.Count {
+				continue
+			}
+			n := it.Count - used[idx]
+			if n > need {
+				n = need
+			}
+			used[idx] += n
+			need -= n
+		}
+		if need > 0 {
+			return Plan{}, ErrMissingInput
+		}
+	}
+	for idx, n := range used {
+		if n > 0 {
+			p.Uses = append(p.Uses, Use{Index: idx, Count: n})
+		}
+	}
+	return p, nil
+}
+
+// pickMaterial returns the material of the first stack matching an input
+// for which the inventory holds at least count unused matching items.
+func pickMaterial(inv *item.Inventory, used []int, count int, match func(*item.Item) bool) (material.ID, bool) {
+	for idx := range inv.Items {
+		if !match(&inv.Items[idx]) {
+			continue
+		}
+		m := inv.Items[idx].Material
+		total := 0
+		for j := range inv.Items {
+			if it := &inv.Items[j]; it.Material == m && match(it) {
+				total += it.Count - used[j]
+			}
+		}
+		if total >= count {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// Apply removes the inputs of the plan from the inventory and returns the
+// crafted items.
+//
+// The inventory must not have changed since the plan was made.
+func (p Plan) Apply(inv *item.Inventory) item.Item {
+	// Remove from the last stack first so earlier indices stay valid
+	uses := append([]Use(nil), p.Uses...)
+	sort.Slice(uses, func(i, j int) bool { return uses[i].Index > uses[j].Index })
+	for _, u := range uses {
+		inv.Remove(u.Index, u.Count)
+	}
+	count := p.Recipe.Count
+	if count < 1 {
+		count = 1
+	}
+	return item.Item{Kind: p.Recipe.Output, Material: p.Material, Count: count}
+}
+
+func hasKind(inv *item.Inventory, kind item.Kind) bool {
+	for i := range inv.Items {
+		if inv.Items[i].Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Requirements returns a short description of what the recipe requires.
+func (r *Recipe) Requirements() string {
+	parts := make([]string, 0, len(r.Inputs)+len(r.Tools)+1)
+	for _, in := range r.Inputs {
+		info := in.Kind.Info()
+		name := info.Name
+		if in.Count > 1 {
+			name = info.Plural
+		}
+		parts = append(parts, fmt.Sprintf("%d %s %s", in.Count, in.Type, name))
+	}
+	for _, tool := range r.Tools {
+		parts = append(parts, tool.String())
+	}
+	if len(r.Workshops) > 0 {
+		names := make([]string, len(r.Workshops))
+		for i, w := range r.Workshops {
+			names[i] = w.String()
+		}
+		parts = append(parts, "at "+strings.Join(names, " or "))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,134 @@
+package craft
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+func TestRecipe(t *testing.T) {
+	t.Parallel()
+	mats := material.DefaultMaterials()
+	const (
+		stone  material.ID = 3
+		iron   material.ID = 5
+		copper material.ID = 6
+		oak    material.ID = 7
+	)
+	pick := &Recipe{
+		Name:   "metal pick",
+		Inputs: []Input{{Kind: item.Bar, Type: material.Metal, Count: 2}},
+		Tools:  []item.Kind{item.Hammer},
+		Output: item.Pick,
+	}
+
+	t.Run("type", func(t *testing.T) {
+		t.Parallel()
+		inv := &item.Inventory{}
+		inv.Add(item.New(item.Hammer, stone))
+		inv.Add(item.Item{Kind: item.Bar, Material: stone, Count: 2})
+		_, err := pick.Plan(inv, mats)
+		assert.ErrorIs(t, err, ErrMissingInput)
+	})
+	t.Run("tool", func(t *testing.T) {
+		t.Parallel()
+		inv := &item.Inventory{}
+		inv.Add(item.Item{Kind: item.Bar, Material: iron, Count: 2})
+		_, err := pick.Plan(inv, mats)
+		assert.ErrorIs(t, err, ErrMissingTool)
+	})
+	t.Run("craft", func(t *testing.T) {
+		t.Parallel()
+		inv := &item.Inventory{}
+		inv.Add(item.New(item.Bar, copper))
+		inv.Add(item.New(item.Hammer, stone))
+		inv.Add(item.Item{Kind: item.Bar, Material: iron, Count: 3})
+		p, err := pick.Plan(inv, mats)
+		require.NoError(t, err)
+		out := p.Apply(inv)
+		// There is only one copper bar, so the pick is made of iron alone
+		assert.Equal(t, item.Item{Kind: item.Pick, Material: iron, Count: 1}, out)
+		require.Equal(t, 3, inv.Len())
+		assert.Equal(t, item.New(item.Bar, copper), inv.Items[0])
+		assert.Equal(t, item.Hammer, inv.Items[1].Kind)
+		assert.Equal(t, 1, inv.Items[2].Count)
+	})
+	t.Run("mixed", func(t *testing.T) {
+		t.Parallel()
+		axe := &Recipe{
+			Name: "metal axe",
+			Inputs: []Input{
+				{Kind: item.Log, Type: material.Wood, Count: 1},
+				{Kind: item.Bar, Type: material.Metal, Count: 2},
+			},
+			Output:       item.Axe,
+			MaterialFrom: 1,
+		}
+		inv := &item.Inventory{}
+		inv.Add(item.New(item.Bar, iron))
+		inv.Add(item.New(item.Bar, stone))
+		_, err := axe.Plan(inv, mats)
+		assert.ErrorIs(t, err, ErrMissingInput)
+
+		inv.Add(item.New(item.Log, oak))
+		inv.Add(item.New(item.Bar, copper))
+		_, err = axe.Plan(inv, mats)
+		assert.ErrorIs(t, err, ErrMissingInput)
+
+		// The output takes the material of the bars, not the log
+		inv.Add(item.New(item.Bar, copper))
+		p, err := axe.Plan(inv, mats)
+		require.NoError(t, err)
+		assert.Equal(t, []Use{{Index: 2, Count: 1}, {Index: 3, Count: 2}}, p.Uses)
+		out := p.Apply(inv)
+		assert.Equal(t, item.Item{Kind: item.Axe, Material: copper, Count: 1}, out)
+		assert.Equal(t, 2, inv.Len())
+	})
+}
+
+func TestLoadRecipes(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "recipes.json")
+	recipes, err := LoadRecipes(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRecipes(), recipes)
+
+	data := `[{"name": "metal axe", "output": "axe",
+		"inputs": [{"kind": "bar", "type": "metal", "count": 1}, {"kind": "log", "type": "wood", "count": 1}],
+		"tools": ["hammer"], "workshops": ["anvil"]}]`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	recipes, err = LoadRecipes(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Recipe{{
+		Name: "metal axe",
+		Inputs: []Input{
+			{Kind: item.Bar, Type: material.Metal, Count: 1},
+			{Kind: item.Log, Type: material.Wood, Count: 1},
+		},
+		Tools:     []item.Kind{item.Hammer},
+		Workshops: []item.Kind{item.Anvil},
+		Output:    item.Axe,
+	}}, recipes)
+
+	for _, data := range []string{
+		`{"name": "axe"}`,
+		`[{"output": "axe", "inputs": [{"kind": "bar", "type": "metal", "count": 1}]}]`,
+		`[{"name": "axe", "output": "axe"}]`,
+		`[{"name": "axe", "output": "sword", "inputs": [{"kind": "bar", "type": "metal", "count": 1}]}]`,
+		`[{"name": "axe", "output": "axe", "inputs": [{"kind": "ingot", "type": "metal", "count": 1}]}]`,
+		`[{"name": "axe", "output": "axe", "inputs": [{"kind": "bar", "type": "cheese", "count": 1}]}]`,
+		`[{"name": "axe", "output": "axe", "inputs": [{"kind": "bar", "type": "metal", "count": 0}]}]`,
+		`[{"name": "axe", "output": "axe", "inputs": [{"kind": "bar", "type": "metal", "count": 1}], "tools": ["spoon"]}]`,
+		`[{"name": "axe", "output": "axe", "inputs": [{"kind": "bar", "type": "metal", "count": 1}], "material_from": 1}]`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		recipes, err := LoadRecipes(path)
+		assert.Error(t, err, data)
+		assert.Equal(t, DefaultRecipes(), recipes)
+	}
+}
@@ -0,0 +1,56 @@
+package creature
+
+import (
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+// DefaultSpecies returns the default set of species.
+func DefaultSpecies() []Species {
+	return []Species{
+		{
+			Name: "rabbit", Plural: "rabbits", Glyph: 'r', Color: color.BrightBrown,
+			Size: 2, Speed: 150, Sight: 10,
+			Diet: Herbivore, Habitat: Surface, Behavior: Flee, Rarity: 10,
+			Body: body.Quadruped, Attack: Attack{Verb: "bites", Damage: 1},
+		},
+		{
+			Name: "deer", Plural: "deer", Glyph: 'd', Color: color.Brown,
+			Size: 70, Speed: 120, Sight: 14,
+			Diet: Herbivore, Habitat: Surface, Behavior: Herd, Rarity: 6,
+			Body: body.Quadruped, Attack: Attack{Verb: "kicks", Damage: 3},
+		},
+		{
+			Name: "boar", Plural: "boars", Glyph: 'b', Color: color.DarkBrown,
+			Size: 80, Speed: 100, Sight: 8,
+			Diet: Omnivore, Habitat: Surface, Behavior: Wander, Rarity: 4,
+			Body: body.Quadruped, Attack: Attack{Verb: "gores", Damage: 4},
+		},
+		{
+			Name: "wolf", Plural: "wolves", Glyph: 'w', Color: color.Gray,
+			Size: 40, Speed: 130, Sight: 16,
+			Diet: Carnivore, Habitat: Surface, Behavior: Hunt, Rarity: 2,
+			Body: body.Quadruped, Attack: Attack{Verb: "bites", Damage: 4},
+		},
+		{
+			Name: "bat", Plural: "bats", Glyph: 'v', Color: color.DarkGray,
+			Size: 0.1, Speed: 160, Sight: 6,
+			Diet: Herbivore, Habitat: Cave, Behavior: Wander, Rarity: 8,
+			Body: body.Winged, Attack: Attack{Verb: "bites", Damage: 1},
+		},
+		{
+			Name: "cave spider", Plural: "cave spiders", Glyph: 's', Color: color.DarkPurple,
+			Size: 5, Speed: 110, Sight: 8,
+			Diet: Carnivore, Habitat: Cave, Behavior: Hunt, Rarity: 4,
+			Body: body.Arachnid, Attack: Attack{Verb: "bites", Damage: 3},
+		},
+		{
+			// Training dummies are never spawned naturally; one is placed
+			// next to the player at the start of each game.
+			Name: "training dummy", Plural: "training dummies", Glyph: '&', Color: color.Brown,
+			Size: 70, Speed: 100, Sight: 0,
+			Diet: Herbivore, Habitat: Surface, Behavior: Still,
+			Body: body.Dummy,
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package creature
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+// The names of the enumerations as used in species files, ordered to match
+// their constants.
+var (
+	dietNames     = []string{"herbivore", "carnivore", "omnivore"}
+	habitatNames  = []string{"surface", "cave"}
+	behaviorNames = []string{"wander", "flee", "hunt", "herd", "still"}
+)
+
+// speciesFile is the format of a single species of a species file.
+type speciesFile struct {
+	Name     string  `json:"name"`
+	Plural   string  `json:"plural"`
+	Glyph    string  `json:"glyph"`
+	Color    string  `json:"color"`
+	Size     float64 `json:"size"`
+	Speed    int     `json:"speed"`
+	Sight    int     `json:"sight"`
+	Diet     string  `json:"diet"`
+	Habitat  string  `json:"habitat"`
+	Behavior string  `json:"behavior"`
+	Body     string  `json:"body"`
+	Attack   struct {
+		Verb   string `json:"verb"`
+		Damage int    `json:"damage"`
+	} `json:"attack"`
+	Rarity int `json:"rarity"`
+}
+
+// LoadSpecies reads the list of species from the JSON file at the given
+// path, looking up the body plans of the species by name in plans.
+//
+// The file holds a list of species, as in:
+//
+//	[{"name": "rabbit", "plural": "rabbits", "glyph": "r", "color": "bright brown",
+//	  "size": 2, "speed": 150, "sight": 10, "diet": "herbivore",
+//	  "habitat": "surface", "behavior": "flee", "body": "quadruped",
+//	  "attack": {"verb": "bites", "damage": 1}, "rarity": 10}]
+//
+// The species of the file replace the default species. If the file doesn't
+// exist, the default species are returned without an error.
+func LoadSpecies(path string, plans []body.Plan) ([]Species, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return DefaultSpecies(), nil
+		}
+		return DefaultSpecies(), err
+	}
+	var entries []speciesFile
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return DefaultSpecies(), fmt.Errorf("invalid species file %q: %w", path, err)
+	}
+	species := make([]Species, 0, len(entries))
+	for i := range entries {
+		sp, err := entries[i].species(plans)
+		if err != nil {
+			return DefaultSpecies(), fmt.Errorf("invalid species file %q: species %d: %w", path, i, err)
+		}
+		species = append(species, sp)
+	}
+	return species, nil
+}
+
+// species returns the species defined by the entry.
+func (e *speciesFile) species(plans []body.Plan) (Species, error) {
+	sp := Species{
+		Name:   e.Name,
+		Plural: e.Plural,
+		Size:   e.Size,
+		Speed:  e.Speed,
+		Sight:  e.Sight,
+		Attack: Attack{Verb: e.Attack.Verb, Damage: e.Attack.Damage},
+		Rarity: e.Rarity,
+	}
+	if sp.Name == "" {
+		return sp, fmt.Errorf("missing name")
+	}
+	if sp.Plural == "" {
+		sp.Plural = sp.Name
+	}
+	if sp.Speed <= 0 {
+		sp.Speed = NormalSpeed
+	}
+
+	glyph := []rune(e.Glyph)
+	if len(glyph) != 1 {
+		return sp, fmt.Errorf("%s: invalid glyph %q", e.Name, e.Glyph)
+	}
+	sp.Glyph = glyph[0]
+
+	c, ok := color.ParseEnum(e.Color)
+	if !ok {
+		return sp, fmt.Errorf("%s: unknown color %q", e.Name, e.Color)
+	}
+	sp.Color = c
+
+	n, err := parseName(e.Name, "diet", e.Diet, dietNames)
+	if err != nil {
+		return sp, err
+	}
+	sp.Diet = Diet(n)
+	if n, err = parseName(e.Name, "habitat", e.Habitat, habitatNames); err != nil {
+		return sp, err
+	}
+	sp.Habitat = Habitat(n)
+	if n, err = parseName(e.Name, "behavior", e.Behavior, behaviorNames); err != nil {
+		return sp, err
+	}
+	sp.Behavior = Behavior(n)
+
+	for i := range plans {
+		if plans[i].Name == e.Body {
+			sp.Body = body.PlanID(i)
+			return sp, nil
+		}
+	}
+	return sp, fmt.Errorf("%s: unknown body plan %q", e.Name, e.Body)
+}
+
+// parseName returns the index of the value in names, or an error naming the
+// species and field if it isn't there.
+func parseName(species, field, value string, names []string) (int, error) {
+	for i, name := range names {
+		if name == value {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown %s %q", species, field, value)
+}
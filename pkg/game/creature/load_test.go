@@ -0,0 +1,57 @@
+package creature
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+func TestLoadSpecies(t *testing.T) {
+	t.Parallel()
+	plans := body.DefaultPlans()
+	path := filepath.Join(t.TempDir(), "species.json")
+
+	species, err := LoadSpecies(path, plans)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSpecies(), species)
+
+	data := `[
+		{"name": "rabbit", "plural": "rabbits", "glyph": "r", "color": "bright brown",
+		 "size": 2, "speed": 150, "sight": 10, "diet": "herbivore", "habitat": "surface",
+		 "behavior": "flee", "body": "quadruped", "attack": {"verb": "bites", "damage": 1},
+		 "rarity": 10},
+		{"name": "mole", "glyph": "m", "color": "dark brown", "size": 0.5, "diet": "carnivore",
+		 "habitat": "cave", "behavior": "wander", "body": "quadruped", "rarity": 3}
+	]`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	species, err = LoadSpecies(path, plans)
+	require.NoError(t, err)
+	require.Len(t, species, 2)
+	assert.Equal(t, DefaultSpecies()[0], species[0])
+	assert.Equal(t, Species{
+		Name: "mole", Plural: "mole", Glyph: 'm', Color: color.DarkBrown,
+		Size: 0.5, Speed: NormalSpeed, Diet: Carnivore, Habitat: Cave, Behavior: Wander,
+		Body: body.Quadruped, Rarity: 3,
+	}, species[1])
+
+	for _, data := range []string{
+		`{"name": "rabbit"}`,
+		`[{"glyph": "r", "color": "brown", "diet": "herbivore", "habitat": "surface", "behavior": "flee", "body": "quadruped"}]`,
+		`[{"name": "rabbit", "glyph": "rr", "color": "brown", "diet": "herbivore", "habitat": "surface", "behavior": "flee", "body": "quadruped"}]`,
+		`[{"name": "rabbit", "glyph": "r", "color": "mauve", "diet": "herbivore", "habitat": "surface", "behavior": "flee", "body": "quadruped"}]`,
+		`[{"name": "rabbit", "glyph": "r", "color": "brown", "diet": "rocks", "habitat": "surface", "behavior": "flee", "body": "quadruped"}]`,
+		`[{"name": "rabbit", "glyph": "r", "color": "brown", "diet": "herbivore", "habitat": "sky", "behavior": "flee", "body": "quadruped"}]`,
+		`[{"name": "rabbit", "glyph": "r", "color": "brown", "diet": "herbivore", "habitat": "surface", "behavior": "dance", "body": "quadruped"}]`,
+		`[{"name": "rabbit", "glyph": "r", "color": "brown", "diet": "herbivore", "habitat": "surface", "behavior": "flee", "body": "blob"}]`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		species, err := LoadSpecies(path, plans)
+		assert.Error(t, err, data)
+		assert.Equal(t, DefaultSpecies(), species)
+	}
+}
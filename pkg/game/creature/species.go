@@ -0,0 +1,98 @@
+package creature
+
+import (
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+// SpeciesID is a species reference ID.
+//
+// This is functionally an index to the species in the list of species.
+type SpeciesID uint16
+
+// Diet is an enumeration of what a species eats.
+type Diet uint8
+
+const (
+	Herbivore Diet = iota
+	Carnivore
+	Omnivore
+)
+
+// Habitat is an enumeration of the parts of the world a species lives in.
+type Habitat uint8
+
+const (
+	// Surface species live on the open ground of the surface.
+	Surface Habitat = iota
+	// Cave species live in the caves below the surface.
+	Cave
+)
+
+// Behavior is an enumeration of the ways creatures act.
+type Behavior uint8
+
+const (
+	// Wander creatures move about at random, ignoring everything else.
+	Wander Behavior = iota
+	// Flee creatures run from any threat they see.
+	Flee
+	// Hunt creatures chase down prey they see.
+	Hunt
+	// Herd creatures stay close to others of their species, and flee from
+	// threats as a group.
+	Herd
+	// Still creatures never move or act.
+	Still
+)
+
+// Attack is the natural attack of a species.
+type Attack struct {
+	// Verb is how the attack is described; e.g. "bites".
+	Verb string
+
+	// Damage is the base damage of the attack. Species with no damage don't
+	// attack.
+	Damage int
+}
+
+// NormalSpeed is the speed of a creature which acts once per turn.
+const NormalSpeed = 100
+
+// Species is the definition of a kind of creature.
+type Species struct {
+	Name   string
+	Plural string
+	Glyph  rune
+	Color  color.Enum
+
+	// Size is the body size of the species, in kg.
+	Size float64
+
+	// Speed is how quickly the species acts, relative to NormalSpeed.
+	Speed int
+
+	// Sight is the distance, in tiles, creatures of the species notice other
+	// creatures at.
+	Sight int
+
+	Diet     Diet
+	Habitat  Habitat
+	Behavior Behavior
+	Body     body.PlanID
+	Attack   Attack
+
+	// Rarity is the relative chance of the species being chosen when a
+	// creature is spawned in its habitat. Species with no rarity are never
+	// spawned naturally.
+	Rarity int
+}
+
+// Preys returns true if creatures of the species would hunt creatures of the
+// other species.
+//
+// Hunters prey on creatures smaller than themselves which aren't also
+// hunters, as long as they eat meat.
+func (s *Species) Preys(o *Species) bool {
+	return s.Behavior == Hunt && s.Diet != Herbivore && o.Behavior != Hunt && o.Size < s.Size
+}
@@ -0,0 +1,224 @@
+package game
+
+import (
+	"log"
+
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/creature"
+)
+
+const (
+	// MaxCreatures is the most creatures which may be in the world before
+	// new creatures stop spawning over time.
+	MaxCreatures = 60
+
+	// SpawnInterval is the number of ticks between attempts to spawn a new
+	// creature.
+	SpawnInterval = 40 * TurnTicks
+
+	// TrainingDummy is the name of the species placed next to the player at
+	// the start of a game, as a harmless target to practice attacking.
+	TrainingDummy = "training dummy"
+)
+
+// Creature is a single living creature in the world.
+type Creature struct {
+	// ID identifies the creature; it is also the ID of the creature's turns
+	// in the turn scheduler.
+	ID      int
+	Species creature.SpeciesID
+	Pos     Coords
+
+	Body *body.Body
+
+	// Seen is true if the creature was visible to the player at the end of
+	// the last turn.
+	Seen bool
+
+	// Hostile is true if the creature has been provoked into attacking the
+	// player.
+	Hostile bool
+
+	// Provoked is true if the creature has been wounded by the player.
+	Provoked bool
+}
+
+// SpeciesOf returns the species of the creature.
+func (g *Game) SpeciesOf(cr *Creature) *creature.Species {
+	return &g.Species[cr.Species]
+}
+
+// CreatureAt returns the creature at the given coordinates.
+//
+// If there is no creature there, this returns nil.
+func (g *Game) CreatureAt(c Coords) *Creature {
+	for _, cr := range g.Creatures {
+		if cr.Pos == c {
+			return cr
+		}
+	}
+	return nil
+}
+
+// creature returns the creature with the given ID, or nil if there is none.
+func (g *Game) creature(id int) *Creature {
+	for _, cr := range g.Creatures {
+		if cr.ID == id {
+			return cr
+		}
+	}
+	return nil
+}
+
+// Occupied returns true if the player or a creature is at the given
+// coordinates.
+func (g *Game) Occupied(c Coords) bool {
+	return c == g.Player || g.CreatureAt(c) != nil
+}
+
+// AddCreature adds a new creature of the given species to the world, and
+// schedules its first turn.
+func (g *Game) AddCreature(sp creature.SpeciesID, pos Coords) *Creature {
+	g.lastCreatureID++
+	species := &g.Species[sp]
+	cr := &Creature{
+		ID:      g.lastCreatureID,
+		Species: sp,
+		Pos:     pos,
+		Body:    body.New(&g.Plans[species.Body], species.Size),
+	}
+	g.Creatures = append(g.Creatures, cr)
+	g.Turns.Schedule(cr.ID, g.Rand.Int63n(TurnTicks))
+	return cr
+}
+
+// RemoveCreature removes the creature from the world.
+func (g *Game) RemoveCreature(cr *Creature) {
+	for i, o := range g.Creatures {
+		if o == cr {
+			g.Creatures = append(g.Creatures[:i], g.Creatures[i+1:]...)
+			break
+		}
+	}
+	g.Turns.Remove(cr.ID)
+}
+
+// HabitatAt returns the habitat of the tile at the given coordinates.
+func (g *Game) HabitatAt(c Coords) creature.Habitat {
+	if ch := g.Chunk(c.Chunk); ch != nil && ch.OpenSky(c.X, c.Y, c.Z) {
+		return creature.Surface
+	}
+	return creature.Cave
+}
+
+// SpawnCreatures spawns the initial creatures of the chunk at the given
+// chunk coordinates, at the positions chosen when the chunk was generated.
+//
+// Each creature is of a species living in the habitat of its position.
+func (g *Game) SpawnCreatures(cc ChunkCoords) {
+	ch := g.Chunk(cc)
+	if ch == nil {
+		return
+	}
+	for _, s := range ch.Spawns {
+		pos := Coords{X: s.X, Y: s.Y, Z: s.Z, Chunk: cc}
+		if g.Occupied(pos) {
+			continue
+		}
+		if sp, ok := g.pickSpecies(g.HabitatAt(pos)); ok {
+			cr := g.AddCreature(sp, pos)
+			log.Printf("game.Game::SpawnCreatures(): Spawned %s %d at %+v", g.SpeciesOf(cr).Name, cr.ID, pos)
+		}
+	}
+}
+
+// PlaceTrainingDummy places a training dummy on a dry tile next to the
+// player, if there is room for one and the TrainingDummy species exists.
+func (g *Game) PlaceTrainingDummy() {
+	for i := range g.Species {
+		if g.Species[i].Name != TrainingDummy {
+			continue
+		}
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				pos := g.Player.Offset(dx, dy, 0)
+				if g.Standable(pos) && g.Tile(pos).Liquid == 0 && !g.Occupied(pos) {
+					// The dummy is part of the starting scene rather than
+					// something to warn the player about.
+					g.AddCreature(creature.SpeciesID(i), pos).Seen = true
+					return
+				}
+			}
+		}
+		return
+	}
+}
+
+// spawnOverTime spawns a new creature somewhere out of sight of the player
+// every SpawnInterval ticks, as long as there are fewer than MaxCreatures.
+func (g *Game) spawnOverTime() {
+	if g.Turns.Now()-g.lastSpawn < SpawnInterval {
+		return
+	}
+	g.lastSpawn = g.Turns.Now()
+	if len(g.Creatures) >= MaxCreatures {
+		return
+	}
+	list := g.ChunkCoordsList()
+	cc := list[g.Rand.Intn(len(list))]
+	habitat := creature.Habitat(g.Rand.Intn(2))
+	g.spawn(cc, habitat, func(c Coords) bool { return !g.IsVisible(c) })
+}
+
+// spawn adds a creature living in the given habitat at a random position in
+// the chunk.
+//
+// If allow is not nil, the creature may only be placed where it returns
+// true. If no position is found, no creature is spawned.
+func (g *Game) spawn(cc ChunkCoords, habitat creature.Habitat, allow func(Coords) bool) {
+	sp, ok := g.pickSpecies(habitat)
+	if !ok {
+		return
+	}
+	for try := 0; try < chunk.SpawnTries; try++ {
+		pos := Coords{X: g.Rand.Intn(chunk.Width), Y: g.Rand.Intn(chunk.Length), Z: chunk.SurfaceLevel, Chunk: cc}
+		if habitat == creature.Cave {
+			pos.Z = chunk.CaveOffset + g.Rand.Intn(chunk.SurfaceLevel-chunk.CaveOffset)
+		}
+		if !g.Standable(pos) || g.Tile(pos).Liquid > 0 || g.HabitatAt(pos) != habitat || g.Occupied(pos) {
+			continue
+		}
+		if allow != nil && !allow(pos) {
+			continue
+		}
+		cr := g.AddCreature(sp, pos)
+		log.Printf("game.Game::spawn(): Spawned %s %d at %+v", g.SpeciesOf(cr).Name, cr.ID, pos)
+		return
+	}
+}
+
+// pickSpecies chooses a random species living in the given habitat, weighted
+// by the rarity of each species.
+func (g *Game) pickSpecies(habitat creature.Habitat) (creature.SpeciesID, bool) {
+	total := 0
+	for i := range g.Species {
+		if g.Species[i].Habitat == habitat {
+			total += g.Species[i].Rarity
+		}
+	}
+	if total <= 0 {
+		return 0, false
+	}
+	n := g.Rand.Intn(total)
+	for i := range g.Species {
+		if g.Species[i].Habitat != habitat {
+			continue
+		}
+		if n < g.Species[i].Rarity {
+			return creature.SpeciesID(i), true
+		}
+		n -= g.Species[i].Rarity
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"log"
+
+	"github.com/tvarney/grogue/pkg/game/chunk"
+)
+
+// CursorMode is an enumeration of the purposes the map cursor is used for.
+type CursorMode int
+
+const (
+	CursorNone CursorMode = iota
+	CursorTravel
+	CursorLook
+)
+
+// Cursor is a position on the map being picked by the player.
+//
+// While the cursor mode is not CursorNone, movement actions move the cursor
+// instead of the player.
+type Cursor struct {
+	Mode CursorMode
+	Pos  Coords
+}
+
+// OpenCursor shows the map cursor at the position of the player.
+func (g *Game) OpenCursor(mode CursorMode) {
+	g.Cursor = Cursor{Mode: mode, Pos: g.Player}
+}
+
+// CloseCursor hides the map cursor.
+func (g *Game) CloseCursor() {
+	g.Cursor = Cursor{}
+}
+
+// Focus returns the position the map display should be centered on.
+//
+// This is the cursor position if the cursor is shown, or the position of the
+// player otherwise.
+func (g *Game) Focus() Coords {
+	if g.Cursor.Mode != CursorNone {
+		return g.Cursor.Pos
+	}
+	return g.Player
+}
+
+// UpdateCursor handles actions while the map cursor is shown.
+func (a *Application) UpdateCursor(action Action) RenderRequest {
+	g := a.Game
+	if dx, dy, dz, ok := action.Direction(); ok {
+		return g.moveCursor(dx, dy, dz)
+	}
+	switch action {
+	case ActionMenuClose:
+		g.CloseCursor()
+		return RenderFull
+	case ActionMenuSelect:
+		pos := g.Cursor.Pos
+		switch g.Cursor.Mode {
+		case CursorTravel:
+			log.Printf("game.Application::UpdateCursor(): Travelling to %v", pos)
+			g.CloseCursor()
+			g.Auto = AutoMove{Mode: AutoTravel, Target: pos}
+			return a.UpdateAuto()
+		}
+	}
+	return RenderNoChange
+}
+
+func (g *Game) moveCursor(dx, dy, dz int) RenderRequest {
+	pos := g.Cursor.Pos.Offset(dx, dy, dz)
+	if pos.Z < 0 || pos.Z >= chunk.Height || g.Chunk(pos.Chunk) == nil {
+		return RenderNoChange
+	}
+	g.Cursor.Pos = pos
+	return RenderIncremental
+}
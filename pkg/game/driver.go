@@ -0,0 +1,41 @@
+package game
+
+import "log"
+
+// Driver is implemented by front-ends which display the game and translate
+// player input into actions.
+type Driver interface {
+	// Init prepares the driver for use, such as by taking over the terminal.
+	Init() error
+
+	// Finalize releases any resources held by the driver.
+	Finalize()
+
+	// PollAction waits for the player to do something, returning the action
+	// the application should be updated with.
+	PollAction(*Application) Action
+
+	// Render displays the current state of the application. The request is
+	// never RenderNoChange; RenderFull asks the driver to redraw everything,
+	// while RenderIncremental allows drawing over the previous frame.
+	Render(*Application, RenderRequest)
+}
+
+// Run initializes the driver and runs the application with it until the
+// application quits, finalizing the driver before returning.
+func Run(app *Application, d Driver) error {
+	if err := d.Init(); err != nil {
+		return err
+	}
+	defer d.Finalize()
+
+	log.Printf("game::Run(): Starting game loop")
+	d.Render(app, RenderFull)
+	for app.Running {
+		if req := app.Update(d.PollAction(app)); req != RenderNoChange && app.Running {
+			d.Render(app, req)
+		}
+	}
+	log.Printf("game::Run(): Game loop finished")
+	return nil
+}
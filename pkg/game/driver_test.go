@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptDriver is a Driver which feeds a fixed list of actions to the
+// application, recording the render requests it receives.
+type scriptDriver struct {
+	actions  []Action
+	renders  []RenderRequest
+	started  bool
+	finished bool
+}
+
+func (s *scriptDriver) Init() error {
+	s.started = true
+	return nil
+}
+
+func (s *scriptDriver) Finalize() {
+	s.finished = true
+}
+
+func (s *scriptDriver) PollAction(*Application) Action {
+	if len(s.actions) == 0 {
+		return ActionQuit
+	}
+	a := s.actions[0]
+	s.actions = s.actions[1:]
+	return a
+}
+
+func (s *scriptDriver) Render(_ *Application, req RenderRequest) {
+	s.renders = append(s.renders, req)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	t.Run("quit", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		d := &scriptDriver{actions: []Action{ActionMenuDown, ActionMenuUp, ActionNone}}
+		require.NoError(t, Run(app, d))
+		assert.True(t, d.started)
+		assert.True(t, d.finished)
+		assert.False(t, app.Running)
+		assert.Equal(t, []RenderRequest{RenderFull, RenderIncremental, RenderIncremental}, d.renders)
+	})
+	t.Run("close main menu", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		d := &scriptDriver{actions: []Action{ActionMenuClose, ActionMenuDown}}
+		require.NoError(t, Run(app, d))
+		assert.False(t, app.Running)
+		assert.Equal(t, []Action{ActionMenuDown}, d.actions)
+		assert.Equal(t, []RenderRequest{RenderFull}, d.renders)
+	})
+	t.Run("play", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		d := &scriptDriver{actions: []Action{
+			ActionMenuSelect, ActionMenuSelect, ActionMenuSelect,
+			ActionMenuDown, ActionMenuDown, ActionMenuSelect,
+			ActionWait,
+		}}
+		require.NoError(t, Run(app, d))
+		assert.True(t, app.InGame)
+		assert.Equal(t, int64(TurnTicks), app.Game.Turns.Now())
+		assert.Equal(t, RenderIncremental, d.renders[len(d.renders)-1])
+	})
+}
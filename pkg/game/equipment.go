@@ -0,0 +1,104 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+// Equipment holds the items the player is wielding and wearing.
+//
+// Equipped items are moved out of the player's inventory, and back into it
+// when unequipped.
+type Equipment struct {
+	Weapon *item.Item
+	Worn   []item.Item
+}
+
+// Weight returns the total weight of the equipment, in kg.
+func (e *Equipment) Weight(mats []*material.Material) float64 {
+	w := 0.0
+	if e.Weapon != nil {
+		w += e.Weapon.Weight(mats)
+	}
+	for i := range e.Worn {
+		w += e.Worn[i].Weight(mats)
+	}
+	return w
+}
+
+// ArmorFor returns the worn item covering the body part with the given
+// name, or nil if the part is unprotected.
+func (e *Equipment) ArmorFor(part string) *item.Item {
+	for i := range e.Worn {
+		for _, name := range e.Worn[i].Kind.Info().Covers {
+			if name == part {
+				return &e.Worn[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Wield wields a single item from the stack at index idx of the player's
+// inventory, putting away any weapon already wielded.
+func (g *Game) Wield(idx int) {
+	if idx < 0 || idx >= g.Inventory.Len() || g.Inventory.Items[idx].Kind.Info().Damage == 0 {
+		g.Message(message.Info, "You can't wield that.")
+		return
+	}
+	it := g.Inventory.Remove(idx, 1)
+	if g.Equipment.Weapon != nil {
+		g.Inventory.Add(*g.Equipment.Weapon)
+	}
+	g.Equipment.Weapon = &it
+	g.Message(message.Info, "You wield %s.", it.Name(g.Materials))
+	g.EndTurn(TurnTicks)
+}
+
+// Unwield puts the wielded weapon back into the player's inventory.
+func (g *Game) Unwield() {
+	if g.Equipment.Weapon == nil {
+		return
+	}
+	it := *g.Equipment.Weapon
+	g.Equipment.Weapon = nil
+	g.Inventory.Add(it)
+	g.Message(message.Info, "You put away %s.", it.Name(g.Materials))
+	g.EndTurn(TurnTicks)
+}
+
+// Wear puts on a single item from the stack at index idx of the player's
+// inventory.
+//
+// Only a single item of each kind may be worn at a time.
+func (g *Game) Wear(idx int) {
+	if idx < 0 || idx >= g.Inventory.Len() || len(g.Inventory.Items[idx].Kind.Info().Covers) == 0 {
+		g.Message(message.Info, "You can't wear that.")
+		return
+	}
+	kind := g.Inventory.Items[idx].Kind
+	for i := range g.Equipment.Worn {
+		if g.Equipment.Worn[i].Kind == kind {
+			g.Message(message.Info, "You are already wearing %s.", g.Equipment.Worn[i].Name(g.Materials))
+			return
+		}
+	}
+	it := g.Inventory.Remove(idx, 1)
+	g.Equipment.Worn = append(g.Equipment.Worn, it)
+	g.Message(message.Info, "You put on %s.", it.Name(g.Materials))
+	g.EndTurn(2 * TurnTicks)
+}
+
+// TakeOff takes off the worn item at index idx, putting it back into the
+// player's inventory.
+func (g *Game) TakeOff(idx int) {
+	if idx < 0 || idx >= len(g.Equipment.Worn) {
+		return
+	}
+	it := g.Equipment.Worn[idx]
+	g.Equipment.Worn = append(g.Equipment.Worn[:idx], g.Equipment.Worn[idx+1:]...)
+	g.Inventory.Add(it)
+	g.Message(message.Info, "You take off %s.", it.Name(g.Materials))
+	g.EndTurn(2 * TurnTicks)
+}
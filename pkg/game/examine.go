@@ -0,0 +1,110 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/material"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+// Examination holds the details of a tile, as shown by look mode.
+//
+// If the tile isn't visible, the details are taken from the remembered
+// appearance of the tile. If the tile has never been seen, Known is false and
+// no details are filled in.
+type Examination struct {
+	Pos         Coords
+	Known       bool
+	Visible     bool
+	Description string
+	Entities    []string
+	Items       []string
+	Parts       []PartInfo
+	Liquid      uint16
+}
+
+// PartInfo describes one of the material parts of a tile.
+type PartInfo struct {
+	// Kind is the part of the tile; one of "block", "floor", or "liquid".
+	Kind     string
+	Name     string
+	Material *material.Material
+	State    *material.State
+}
+
+// Examine returns the details of the tile at the given coordinates.
+func (g *Game) Examine(c Coords) Examination {
+	e := Examination{Pos: c, Visible: g.IsVisible(c), Description: "unknown"}
+	t := g.Recall(c)
+	if e.Visible {
+		t = g.Tile(c)
+	}
+	if t == nil {
+		return e
+	}
+
+	e.Known = true
+	e.Description = t.Describe(g.Blocks, g.Floors, g.Materials)
+	if e.Visible && c == g.Player {
+		e.Entities = append(e.Entities, "you")
+	}
+	if cr := g.CreatureAt(c); e.Visible && cr != nil {
+		name := g.SpeciesOf(cr).Name
+		if w := Wounds(cr.Body); w != "" {
+			name += " (" + w + ")"
+		}
+		e.Entities = append(e.Entities, name)
+	}
+	if items := g.ItemsAt(c); e.Visible && items != nil {
+		for i := range items.Items {
+			e.Items = append(e.Items, items.Items[i].Name(g.Materials))
+		}
+	}
+
+	if t.Block.Definition != tile.BlockEmpty {
+		mat := g.Materials[t.Block.Material]
+		e.Parts = append(e.Parts, PartInfo{
+			Kind:     "block",
+			Name:     g.Blocks[t.Block.Definition].GetName(mat),
+			Material: mat,
+			State:    &mat.Solid,
+		})
+	}
+	if t.Floor.Definition != tile.FloorEmpty {
+		mat := g.Materials[t.Floor.Material]
+		name := g.Floors[t.Floor.Definition].GetName(mat)
+		if t.Flags&tile.HasGrass != 0 {
+			name = "grassy " + name
+		}
+		e.Parts = append(e.Parts, PartInfo{
+			Kind:     "floor",
+			Name:     name,
+			Material: mat,
+			State:    &mat.Solid,
+		})
+	}
+	if t.Liquid > 0 {
+		mat := g.Materials[t.LiquidMat]
+		e.Liquid = t.Liquid
+		e.Parts = append(e.Parts, PartInfo{
+			Kind:     "liquid",
+			Name:     mat.Liquid.Name,
+			Material: mat,
+			State:    &mat.Liquid,
+		})
+	}
+	return e
+}
+
+// Wounds returns a short description of how badly the body is wounded, or an
+// empty string if it is unharmed.
+func Wounds(b *body.Body) string {
+	switch c := b.Condition(); {
+	case c >= 1.0:
+		return ""
+	case c > 0.66:
+		return "lightly wounded"
+	case c > 0.33:
+		return "wounded"
+	}
+	return "badly wounded"
+}
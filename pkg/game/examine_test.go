@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/material"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+func TestUpdateCursor(t *testing.T) {
+	t.Parallel()
+	app := newTestApp(t)
+	g := app.Game
+	start := g.Player
+
+	app.Update(ActionLook)
+	require.Equal(t, CursorLook, g.Cursor.Mode)
+	assert.Equal(t, start, g.Focus())
+
+	// Movement actions move the cursor, including between z-levels
+	app.Update(ActionMoveDown)
+	app.Update(ActionMoveDown)
+	app.Update(ActionMoveNorthEast)
+	assert.Equal(t, start.Offset(1, -1, -2), g.Cursor.Pos)
+	assert.Equal(t, g.Cursor.Pos, g.Focus())
+	app.Update(ActionMoveUp)
+	assert.Equal(t, start.Offset(1, -1, -1), g.Cursor.Pos)
+	assert.Equal(t, start, g.Player)
+
+	// The cursor can't leave the world
+	g.Cursor.Pos.Z = chunk.Height - 1
+	assert.Equal(t, RenderNoChange, app.Update(ActionMoveUp))
+	assert.Equal(t, chunk.Height-1, g.Cursor.Pos.Z)
+
+	app.Update(ActionMenuClose)
+	assert.Equal(t, CursorNone, g.Cursor.Mode)
+	assert.Equal(t, start, g.Focus())
+}
+
+func TestExamine(t *testing.T) {
+	t.Parallel()
+	g := newTestGame(t)
+	const water, stone, iron = 1, 3, 5
+
+	// A pool of water on a stone floor, with a wolf swimming in it above a
+	// dropped axe
+	pool := g.Player.Offset(1, 0, 0)
+	*g.Tile(pool) = tile.State{
+		Block:     tile.Part{Definition: tile.BlockEmpty},
+		Floor:     tile.Part{Definition: tile.FloorStone, Material: stone},
+		Liquid:    3,
+		LiquidMat: water,
+	}
+	g.AddCreature(speciesID(t, g, "wolf"), pool)
+	g.Chunk(pool.Chunk).DropItem(pool.X, pool.Y, pool.Z, item.Item{Kind: item.Axe, Material: iron, Count: 1})
+
+	// An iron wall, which is remembered but out of sight
+	wall := g.Player.Offset(0, 1, 0)
+	*g.Tile(wall) = tile.State{
+		Block: tile.Part{Definition: tile.BlockStone, Material: iron},
+		Floor: tile.Part{Definition: tile.FloorStone, Material: iron},
+	}
+	g.UpdateView()
+
+	e := g.Examine(pool)
+	assert.True(t, e.Known)
+	assert.True(t, e.Visible)
+	assert.Equal(t, "water", e.Description)
+	assert.Equal(t, []string{"wolf"}, e.Entities)
+	assert.Equal(t, []string{"iron axe"}, e.Items)
+	assert.Equal(t, uint16(3), e.Liquid)
+	require.Len(t, e.Parts, 2)
+	assert.Equal(t, "floor", e.Parts[0].Kind)
+	assert.Equal(t, "stone", e.Parts[0].Name)
+	assert.Equal(t, material.Stone, e.Parts[0].Material.Type)
+	assert.Equal(t, &g.Materials[stone].Solid, e.Parts[0].State)
+	assert.Equal(t, "liquid", e.Parts[1].Kind)
+	assert.Equal(t, "water", e.Parts[1].Name)
+	assert.Equal(t, &g.Materials[water].Liquid, e.Parts[1].State)
+
+	e = g.Examine(g.Player)
+	assert.Equal(t, []string{"you"}, e.Entities)
+
+	// Tiles out of sight show what was remembered, without creatures or items
+	g.Player = g.Player.Offset(0, 0, 10)
+	g.UpdateView()
+	e = g.Examine(wall)
+	assert.True(t, e.Known)
+	assert.False(t, e.Visible)
+	assert.Empty(t, e.Entities)
+	require.Len(t, e.Parts, 2)
+	assert.Equal(t, "block", e.Parts[0].Kind)
+	assert.Equal(t, "iron", e.Parts[0].Name)
+	assert.Equal(t, material.Metal, e.Parts[0].Material.Type)
+	e = g.Examine(pool)
+	assert.Empty(t, e.Entities)
+	assert.Empty(t, e.Items)
+
+	// Tiles never seen are unknown
+	e = g.Examine(wall.Offset(0, 0, -5))
+	assert.False(t, e.Known)
+	assert.Equal(t, "unknown", e.Description)
+	assert.Empty(t, e.Parts)
+}
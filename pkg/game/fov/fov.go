@@ -0,0 +1,78 @@
+package fov
+
+// Map is the interface the field of view calculation uses to query the world.
+type Map interface {
+	// BlocksSight returns true if the tile at (x,y) stops line of sight.
+	BlocksSight(x, y int) bool
+}
+
+// octants holds the transformation matrices for each of the 8 octants.
+var octants = [8][4]int{
+	{1, 0, 0, 1},
+	{0, 1, 1, 0},
+	{0, -1, 1, 0},
+	{-1, 0, 0, 1},
+	{-1, 0, 0, -1},
+	{0, -1, -1, 0},
+	{0, 1, -1, 0},
+	{1, 0, 0, -1},
+}
+
+// Compute calculates the field of view from (ox,oy).
+//
+// The visit function is called for every tile within radius of the origin
+// which is visible from it, including the origin itself and the tiles which
+// block sight. Tiles may be visited more than once. This uses recursive
+// shadowcasting, which treats every tile as a full square.
+func Compute(m Map, ox, oy, radius int, visit func(x, y int)) {
+	visit(ox, oy)
+	for _, oct := range octants {
+		cast(m, ox, oy, radius, 1, 1.0, 0.0, oct, visit)
+	}
+}
+
+func cast(m Map, ox, oy, radius, row int, start, end float64, oct [4]int, visit func(x, y int)) {
+	if start < end {
+		return
+	}
+
+	r2 := radius * radius
+	newStart := 0.0
+	for j := row; j <= radius; j++ {
+		blocked := false
+		for dx, dy := -j-1, -j; dx <= 0; {
+			dx++
+			lslope := (float64(dx) - 0.5) / (float64(dy) + 0.5)
+			rslope := (float64(dx) + 0.5) / (float64(dy) - 0.5)
+			if start < rslope {
+				continue
+			}
+			if end > lslope {
+				break
+			}
+
+			x := ox + dx*oct[0] + dy*oct[1]
+			y := oy + dx*oct[2] + dy*oct[3]
+			if dx*dx+dy*dy <= r2 {
+				visit(x, y)
+			}
+
+			opaque := m.BlocksSight(x, y)
+			if blocked {
+				if opaque {
+					newStart = rslope
+					continue
+				}
+				blocked = false
+				start = newStart
+			} else if opaque && j < radius {
+				blocked = true
+				cast(m, ox, oy, radius, j+1, start, lslope, oct, visit)
+				newStart = rslope
+			}
+		}
+		if blocked {
+			break
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package fov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type grid []string
+
+func (g grid) BlocksSight(x, y int) bool {
+	if y < 0 || y >= len(g) || x < 0 || x >= len(g[y]) {
+		return true
+	}
+	return g[y][x] == '#'
+}
+
+func visible(g grid, ox, oy, radius int) map[[2]int]bool {
+	seen := map[[2]int]bool{}
+	Compute(g, ox, oy, radius, func(x, y int) {
+		seen[[2]int{x, y}] = true
+	})
+	return seen
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+	t.Run("open", func(t *testing.T) {
+		t.Parallel()
+		g := grid{
+			".....",
+			".....",
+			".....",
+			".....",
+			".....",
+		}
+		seen := visible(g, 2, 2, 2)
+		assert.True(t, seen[[2]int{2, 2}], "origin")
+		assert.True(t, seen[[2]int{2, 0}])
+		assert.True(t, seen[[2]int{0, 2}])
+		assert.True(t, seen[[2]int{1, 1}])
+		assert.False(t, seen[[2]int{0, 0}], "outside of radius")
+	})
+	t.Run("wall", func(t *testing.T) {
+		t.Parallel()
+		g := grid{
+			".......",
+			".......",
+			"...#...",
+			".......",
+			".......",
+		}
+		seen := visible(g, 3, 4, 5)
+		assert.True(t, seen[[2]int{3, 2}], "wall is visible")
+		assert.False(t, seen[[2]int{3, 1}], "behind wall")
+		assert.False(t, seen[[2]int{3, 0}], "behind wall")
+		assert.True(t, seen[[2]int{0, 4}])
+	})
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const (
+	InventoryMenuID = "inventory"
+	ItemMenuID      = "inventory-item"
+)
+
+// ItemsAt returns the items lying on the tile at the given coordinates.
+//
+// If there are no items on the tile, or the tile isn't loaded, this returns
+// nil.
+func (g *Game) ItemsAt(c Coords) *item.Inventory {
+	if ch := g.Chunk(c.Chunk); ch != nil {
+		return ch.ItemsAt(c.X, c.Y, c.Z)
+	}
+	return nil
+}
+
+// PickUp moves every item on the player's tile into the player's inventory.
+func (g *Game) PickUp() {
+	ch := g.Chunk(g.Player.Chunk)
+	items := g.ItemsAt(g.Player)
+	if ch == nil || items == nil {
+		g.Message(message.Info, "There is nothing here to pick up.")
+		return
+	}
+	for items.Len() > 0 {
+		it := ch.TakeItem(g.Player.X, g.Player.Y, g.Player.Z, 0, items.Items[0].Count)
+		g.Inventory.Add(it)
+		g.Message(message.Info, "You pick up %s.", it.Name(g.Materials))
+	}
+	g.EndTurn(TurnTicks)
+}
+
+// Drop moves up to count items from the stack at index idx of the player's
+// inventory onto the player's tile.
+func (g *Game) Drop(idx, count int) {
+	ch := g.Chunk(g.Player.Chunk)
+	if ch == nil {
+		return
+	}
+	it := g.Inventory.Remove(idx, count)
+	if it.Count == 0 {
+		return
+	}
+	ch.DropItem(g.Player.X, g.Player.Y, g.Player.Z, it)
+	g.Message(message.Info, "You drop %s.", it.Name(g.Materials))
+	g.EndTurn(TurnTicks)
+}
+
+// NewInventoryMenu returns a new StaticMenu instance listing the items the
+// player carries.
+//
+// Selecting an item opens the item menu for it. Equipped items are listed
+// after the carried items, and selecting one unequips it.
+func NewInventoryMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    InventoryMenuID,
+		Title: "Inventory",
+	}
+	var refresh func(*Application)
+	refresh = func(app *Application) {
+		g := app.Game
+		weight := g.Inventory.Weight(g.Materials) + g.Equipment.Weight(g.Materials)
+		m.Title = fmt.Sprintf("Inventory (%.1f kg)", weight)
+		m.Options = make([]string, 0, g.Inventory.Len()+len(g.Equipment.Worn)+1)
+		m.Colors = make([]color.Enum, 0, cap(m.Options))
+		m.Actions = make([]func(*Application) RenderRequest, 0, cap(m.Options))
+		add := func(it *item.Item, suffix string, action func(*Application) RenderRequest) {
+			m.Options = append(m.Options, fmt.Sprintf("%s (%.1f kg)%s", it.Name(g.Materials), it.Weight(g.Materials), suffix))
+			m.Colors = append(m.Colors, it.Color(g.Materials))
+			m.Actions = append(m.Actions, action)
+		}
+		for i := range g.Inventory.Items {
+			add(&g.Inventory.Items[i], "", func(app *Application) RenderRequest {
+				app.PushMenu(ItemMenuID)
+				return RenderFull
+			})
+		}
+
+		// Equipped items are listed after the inventory; selecting one
+		// unequips it
+		if g.Equipment.Weapon != nil {
+			add(g.Equipment.Weapon, " (wielded)", func(app *Application) RenderRequest {
+				app.Game.Unwield()
+				refresh(app)
+				return RenderFull
+			})
+		}
+		for i := range g.Equipment.Worn {
+			idx := i
+			add(&g.Equipment.Worn[i], " (worn)", func(app *Application) RenderRequest {
+				app.Game.TakeOff(idx)
+				refresh(app)
+				return RenderFull
+			})
+		}
+		if len(m.Options) == 0 {
+			m.Title = "Inventory (empty)"
+		}
+		m.SetOption(m.Cursor)
+	}
+	m.OnStart = refresh
+	m.OnResume = refresh
+	return m
+}
+
+// NewItemMenu returns a new StaticMenu instance for acting on the item
+// selected in the inventory menu.
+func NewItemMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID: ItemMenuID,
+	}
+	m.OnStart = func(app *Application) {
+		idx := app.menus[InventoryMenuID].GetOption()
+		if idx < 0 || idx >= app.Game.Inventory.Len() {
+			m.Title = ""
+			m.Options = nil
+			m.Actions = nil
+			return
+		}
+		it := &app.Game.Inventory.Items[idx]
+		drop := func(count int) func(*Application) RenderRequest {
+			return func(app *Application) RenderRequest {
+				app.Game.Drop(idx, count)
+				app.PopMenu()
+				return RenderFull
+			}
+		}
+		m.Title = it.Name(app.Game.Materials)
+		m.Options = []string{"Drop"}
+		m.Actions = []func(*Application) RenderRequest{drop(it.Count)}
+		if it.Kind.Info().Damage > 0 {
+			m.Options = append(m.Options, "Wield")
+			m.Actions = append(m.Actions, func(app *Application) RenderRequest {
+				app.Game.Wield(idx)
+				app.PopMenu()
+				return RenderFull
+			})
+		}
+		if Edible(it) {
+			m.Options = append(m.Options, "Eat")
+			m.Actions = append(m.Actions, func(app *Application) RenderRequest {
+				app.Game.Eat(idx)
+				app.PopMenu()
+				return RenderFull
+			})
+		}
+		if len(it.Kind.Info().Covers) > 0 {
+			m.Options = append(m.Options, "Wear")
+			m.Actions = append(m.Actions, func(app *Application) RenderRequest {
+				app.Game.Wear(idx)
+				app.PopMenu()
+				return RenderFull
+			})
+		}
+		if it.Count > 1 {
+			m.Options = append(m.Options, "Drop one")
+			m.Actions = append(m.Actions, drop(1))
+		}
+		m.Options = append(m.Options, "Cancel")
+		m.Actions = append(m.Actions, func(app *Application) RenderRequest {
+			app.PopMenu()
+			return RenderFull
+		})
+	}
+	return m
+}
@@ -0,0 +1,57 @@
+package item
+
+import "github.com/tvarney/grogue/pkg/game/material"
+
+// Inventory is an ordered collection of item stacks.
+type Inventory struct {
+	Items []Item
+}
+
+// Len returns the number of stacks in the inventory.
+func (inv *Inventory) Len() int {
+	return len(inv.Items)
+}
+
+// Add adds items to the inventory.
+//
+// If the items stack with an existing stack, they are merged into it;
+// otherwise they are added as a new stack at the end of the inventory.
+func (inv *Inventory) Add(it Item) {
+	if it.Count <= 0 {
+		return
+	}
+	for i := range inv.Items {
+		if inv.Items[i].Stacks(&it) {
+			inv.Items[i].Count += it.Count
+			return
+		}
+	}
+	inv.Items = append(inv.Items, it)
+}
+
+// Remove removes up to count items from the stack at the given index.
+//
+// The removed items are returned as a new stack. If every item of the stack
+// is removed, the stack is removed from the inventory.
+func (inv *Inventory) Remove(idx, count int) Item {
+	if idx < 0 || idx >= len(inv.Items) || count <= 0 {
+		return Item{}
+	}
+	it := inv.Items[idx]
+	if count >= it.Count {
+		inv.Items = append(inv.Items[:idx], inv.Items[idx+1:]...)
+		return it
+	}
+	inv.Items[idx].Count -= count
+	it.Count = count
+	return it
+}
+
+// Weight returns the total weight of the inventory, in kg.
+func (inv *Inventory) Weight(mats []*material.Material) float64 {
+	w := 0.0
+	for i := range inv.Items {
+		w += inv.Items[i].Weight(mats)
+	}
+	return w
+}
@@ -0,0 +1,48 @@
+package item
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+func TestInventory(t *testing.T) {
+	t.Parallel()
+	mats := material.DefaultMaterials()
+	t.Run("stack", func(t *testing.T) {
+		t.Parallel()
+		inv := Inventory{}
+		inv.Add(New(Rock, 3))
+		inv.Add(Item{Kind: Rock, Material: 3, Count: 2})
+		inv.Add(New(Rock, 4))
+		require.Equal(t, 2, inv.Len())
+		assert.Equal(t, 3, inv.Items[0].Count)
+		assert.Equal(t, "3 stone rocks", inv.Items[0].Name(mats))
+	})
+	t.Run("tools", func(t *testing.T) {
+		t.Parallel()
+		inv := Inventory{}
+		inv.Add(New(Pick, 5))
+		inv.Add(New(Pick, 5))
+		assert.Equal(t, 2, inv.Len())
+	})
+	t.Run("remove", func(t *testing.T) {
+		t.Parallel()
+		inv := Inventory{}
+		inv.Add(Item{Kind: Rock, Material: 3, Count: 3})
+		inv.Add(New(Gem, 8))
+
+		it := inv.Remove(0, 1)
+		assert.Equal(t, 1, it.Count)
+		require.Equal(t, 2, inv.Len())
+		assert.Equal(t, 2, inv.Items[0].Count)
+
+		it = inv.Remove(0, 5)
+		assert.Equal(t, 2, it.Count)
+		require.Equal(t, 1, inv.Len())
+		assert.Equal(t, Gem, inv.Items[0].Kind)
+		assert.InDelta(t, 0.053, inv.Weight(mats), 0.001)
+	})
+}
@@ -0,0 +1,163 @@
+package item
+
+import (
+	"fmt"
+
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/material"
+)
+
+// Kind is an enumeration of the kinds of items.
+//
+// The kind of an item determines its shape and use, while the material of an
+// item determines its name, color, and weight.
+type Kind uint16
+
+const (
+	Rock Kind = iota
+	Boulder
+	Bar
+	Gem
+	Pick
+	Axe
+	Hammer
+	Anvil
+	Helmet
+	Cuirass
+	Corpse
+	Bone
+	Log
+
+	kindCount
+)
+
+// KindInfo holds the static details of an item kind.
+type KindInfo struct {
+	Name   string
+	Plural string
+
+	// Volume is the volume of a single item of the kind, in liters.
+	Volume float64
+
+	// Stackable is true if items of the kind may be stacked together.
+	Stackable bool
+
+	// Tool is true if items of the kind are tools.
+	Tool bool
+
+	// Workshop is true if items of the kind are workshops, which crafting
+	// recipes may require to be nearby.
+	Workshop bool
+
+	// Damage is the base damage of items of the kind when used as a weapon.
+	// Items with no damage can't be wielded.
+	Damage int
+
+	// Covers lists the names of the body parts items of the kind protect
+	// when worn. Items which cover no parts can't be worn.
+	Covers []string
+}
+
+var kinds = [kindCount]KindInfo{
+	{Name: "rock", Plural: "rocks", Volume: 0.5, Stackable: true, Damage: 2},
+	{Name: "boulder", Plural: "boulders", Volume: 25.0},
+	{Name: "bar", Plural: "bars", Volume: 0.5, Stackable: true, Damage: 2},
+	{Name: "gem", Plural: "gems", Volume: 0.02, Stackable: true},
+	{Name: "pick", Plural: "picks", Volume: 0.4, Tool: true, Damage: 4},
+	{Name: "axe", Plural: "axes", Volume: 0.3, Tool: true, Damage: 5},
+	{Name: "hammer", Plural: "hammers", Volume: 0.3, Tool: true, Damage: 4},
+	{Name: "anvil", Plural: "anvils", Volume: 15.0, Workshop: true},
+	{Name: "helmet", Plural: "helmets", Volume: 0.3, Covers: []string{"head"}},
+	{Name: "cuirass", Plural: "cuirasses", Volume: 1.2, Covers: []string{"torso", "body"}},
+	{Name: "corpse", Plural: "corpses", Volume: 1.0},
+	{Name: "bone", Plural: "bones", Volume: 0.2, Stackable: true},
+	{Name: "log", Plural: "logs", Volume: 2.0, Stackable: true, Damage: 3},
+}
+
+// Info returns the static details of the item kind.
+func (k Kind) Info() *KindInfo {
+	return &kinds[k]
+}
+
+// Kinds returns every item kind, in order.
+func Kinds() []Kind {
+	ks := make([]Kind, kindCount)
+	for i := range ks {
+		ks[i] = Kind(i)
+	}
+	return ks
+}
+
+// ParseKind returns the item kind with the given name, as returned by
+// String.
+func ParseKind(name string) (Kind, bool) {
+	for k := Kind(0); k < kindCount; k++ {
+		if kinds[k].Name == name {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// String returns the name of the item kind.
+func (k Kind) String() string {
+	if k >= kindCount {
+		return "unknown"
+	}
+	return kinds[k].Name
+}
+
+// Item is a stack of one or more identical items.
+type Item struct {
+	Kind     Kind
+	Material material.ID
+	Count    int
+
+	// Label replaces the material adjective in the name of the item if set;
+	// e.g. the species of a corpse.
+	Label string
+
+	// Volume replaces the volume of the kind for a single item if set, for
+	// items such as corpses which vary in size.
+	Volume float64
+}
+
+// New returns a single item of the given kind and material.
+func New(kind Kind, mat material.ID) Item {
+	return Item{Kind: kind, Material: mat, Count: 1}
+}
+
+// Name returns the name of the item, derived from its material.
+//
+// Stacks of more than one item are prefixed with the number of items.
+func (i *Item) Name(mats []*material.Material) string {
+	info := i.Kind.Info()
+	adj := mats[i.Material].Solid.Adjective
+	if i.Label != "" {
+		adj = i.Label
+	}
+	if i.Count > 1 {
+		return fmt.Sprintf("%d %s %s", i.Count, adj, info.Plural)
+	}
+	return fmt.Sprintf("%s %s", adj, info.Name)
+}
+
+// Color returns the color of the item, derived from its material.
+func (i *Item) Color(mats []*material.Material) color.Enum {
+	return mats[i.Material].Solid.Color
+}
+
+// Weight returns the total weight of the stack, in kg.
+func (i *Item) Weight(mats []*material.Material) float64 {
+	vol := i.Kind.Info().Volume
+	if i.Volume > 0 {
+		vol = i.Volume
+	}
+	return vol * mats[i.Material].Density * float64(i.Count)
+}
+
+// Stacks returns true if the item may be stacked with the other item.
+func (i *Item) Stacks(o *Item) bool {
+	return i.Kind == o.Kind && i.Material == o.Material && i.Label == o.Label &&
+		i.Volume == o.Volume && i.Kind.Info().Stackable
+}
@@ -0,0 +1,486 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+const (
+	KeyContextsMenuID = "key-contexts"
+	KeyBindingsMenuID = "key-bindings"
+	KeyCaptureMenuID  = "key-capture"
+)
+
+// The contexts keys are bound in. Drivers use the menu context while a menu
+// is shown, the map context while the overview map is shown, the look context
+// while the map cursor is shown, and the game context otherwise.
+const (
+	KeyContextGame = "game"
+	KeyContextMenu = "menu"
+	KeyContextLook = "look"
+	KeyContextMap  = "map"
+)
+
+// KeyContexts are the key binding contexts, in the order they are shown in
+// the key binding editor.
+var KeyContexts = []string{KeyContextGame, KeyContextMenu, KeyContextLook, KeyContextMap}
+
+// requiredActions are the actions of each context which must always be bound
+// to a key, so that the player can't lock themselves out of the menus.
+var requiredActions = map[string][]Action{
+	KeyContextMenu: {ActionMenuSelect, ActionMenuClose, ActionQuit},
+}
+
+var keyContextNames = map[string]string{
+	KeyContextGame: "Game",
+	KeyContextMenu: "Menus",
+	KeyContextLook: "Look and Travel",
+	KeyContextMap:  "World Map",
+}
+
+// Bindings maps actions to the names of the keys which trigger them.
+//
+// Key names are defined by the driver; the terminal driver uses single
+// characters for printable keys and names such as "Up", "Enter", and
+// "Ctrl+P" for others.
+type Bindings map[Action][]string
+
+// Keymap holds the key bindings of each context.
+type Keymap map[string]Bindings
+
+// Conflict is a key which is bound to more than one action in a context.
+type Conflict struct {
+	Context string
+	Key     string
+	Actions []Action
+}
+
+// Error returns a description of the conflict.
+func (c Conflict) Error() string {
+	names := make([]string, len(c.Actions))
+	for i, a := range c.Actions {
+		names[i] = a.String()
+	}
+	return fmt.Sprintf("%s: %q is bound to %s", c.Context, c.Key, strings.Join(names, ", "))
+}
+
+// movementBindings returns the bindings of the movement actions; the vi-keys,
+// the arrow keys, and the numeric keypad with and without num-lock.
+func movementBindings() Bindings {
+	return Bindings{
+		ActionMoveNorth:     {"k", "8", "Up"},
+		ActionMoveSouth:     {"j", "2", "Down"},
+		ActionMoveEast:      {"l", "6", "Right"},
+		ActionMoveWest:      {"h", "4", "Left"},
+		ActionMoveNorthEast: {"u", "9", "PgUp"},
+		ActionMoveNorthWest: {"y", "7", "Home"},
+		ActionMoveSouthEast: {"n", "3", "PgDn"},
+		ActionMoveSouthWest: {"b", "1", "End"},
+		ActionMoveUp:        {">"},
+		ActionMoveDown:      {"<"},
+	}
+}
+
+// DefaultKeymap returns the key bindings used when the user hasn't chosen
+// any.
+func DefaultKeymap() Keymap {
+	game := movementBindings()
+	game[ActionWait] = []string{".", "5"}
+	game[ActionTravel] = []string{"_"}
+	game[ActionLook] = []string{"x", ";"}
+	game[ActionExplore] = []string{"o"}
+	game[ActionMessageLog] = []string{"P", "Ctrl+P"}
+	game[ActionPickUp] = []string{",", "g"}
+	game[ActionInventory] = []string{"i"}
+	game[ActionCraft] = []string{"c"}
+	game[ActionDrink] = []string{"q"}
+	game[ActionRest] = []string{"Z"}
+	game[ActionSideView] = []string{"v"}
+	game[ActionOverview] = []string{"m"}
+	game[ActionQuit] = []string{"Ctrl+C"}
+
+	look := movementBindings()
+	look[ActionMenuSelect] = []string{".", "5", "Enter"}
+	look[ActionMenuClose] = []string{"Esc"}
+	look[ActionQuit] = []string{"Ctrl+C"}
+
+	overview := movementBindings()
+	delete(overview, ActionMoveUp)
+	delete(overview, ActionMoveDown)
+	overview[ActionZoomIn] = []string{"+", "="}
+	overview[ActionZoomOut] = []string{"-"}
+	overview[ActionMenuClose] = []string{"Esc", "m"}
+	overview[ActionQuit] = []string{"Ctrl+C"}
+
+	return Keymap{
+		KeyContextGame: game,
+		KeyContextLook: look,
+		KeyContextMap:  overview,
+		KeyContextMenu: {
+			ActionMenuUp:     {"k", "Up"},
+			ActionMenuDown:   {"j", "Down"},
+			ActionMenuLeft:   {"h", "Left"},
+			ActionMenuRight:  {"l", "Right"},
+			ActionMenuSelect: {"Enter"},
+			ActionMenuClose:  {"Esc"},
+			ActionQuit:       {"Ctrl+C"},
+		},
+	}
+}
+
+// Actions returns the actions with bindings in the context, in order.
+func (b Bindings) Actions() []Action {
+	actions := make([]Action, 0, len(b))
+	for a := range b {
+		actions = append(actions, a)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
+}
+
+// Lookup returns the action bound to the key in the given context, or
+// ActionNone if the key isn't bound.
+//
+// If the key is bound to more than one action, the first action is returned.
+func (k Keymap) Lookup(context, key string) Action {
+	b := k[context]
+	for a := ActionQuit; a < actionCount; a++ {
+		for _, bound := range b[a] {
+			if bound == key {
+				return a
+			}
+		}
+	}
+	return ActionNone
+}
+
+// Required returns true if the action must always be bound to a key in the
+// given context.
+func Required(context string, action Action) bool {
+	for _, a := range requiredActions[context] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Bind adds the key to the bindings of the action in the given context.
+//
+// The key is removed from any other action it was bound to in the context.
+// If that would leave a required action with no keys, nothing is changed and
+// this returns false.
+func (k Keymap) Bind(context string, action Action, key string) bool {
+	b := k[context]
+	if b == nil {
+		b = Bindings{}
+		k[context] = b
+	}
+	for a, keys := range b {
+		if a != action && Required(context, a) && len(keys) == 1 && keys[0] == key {
+			return false
+		}
+	}
+	for a, keys := range b {
+		if a != action {
+			b[a] = without(keys, key)
+		}
+	}
+	if indexOf(b[action], key) < 0 {
+		b[action] = append(b[action], key)
+	}
+	return true
+}
+
+// Unbind removes every key bound to the action in the given context.
+//
+// Required actions can't be unbound; for those, nothing is changed and this
+// returns false.
+func (k Keymap) Unbind(context string, action Action) bool {
+	if Required(context, action) {
+		return false
+	}
+	if b := k[context]; b != nil {
+		b[action] = []string{}
+	}
+	return true
+}
+
+// Conflicts returns the keys bound to more than one action in the same
+// context, ordered by context and key.
+func (k Keymap) Conflicts() []Conflict {
+	conflicts := []Conflict{}
+	contexts := make([]string, 0, len(k))
+	for ctx := range k {
+		contexts = append(contexts, ctx)
+	}
+	sort.Strings(contexts)
+	for _, ctx := range contexts {
+		bound := map[string][]Action{}
+		for _, a := range k[ctx].Actions() {
+			for _, key := range k[ctx][a] {
+				bound[key] = append(bound[key], a)
+			}
+		}
+		keys := make([]string, 0, len(bound))
+		for key, actions := range bound {
+			if len(actions) > 1 {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			conflicts = append(conflicts, Conflict{Context: ctx, Key: key, Actions: bound[key]})
+		}
+	}
+	return conflicts
+}
+
+// DefaultKeymapPath returns the default path of the key bindings file; a
+// file under the user's config directory.
+//
+// If the user config directory can't be determined, this returns an empty
+// string.
+func DefaultKeymapPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		log.Printf("game::DefaultKeymapPath(): %v", err)
+		return ""
+	}
+	return filepath.Join(dir, "grogue", "keys.json")
+}
+
+// LoadKeymap reads key bindings from the file at the given path.
+//
+// The file holds the bindings of each context keyed by action name, such as
+// {"game": {"wait": [".", "5"]}}. Actions listed in the file replace the
+// default bindings of the action; all other actions keep their defaults.
+// Unknown contexts and actions are ignored, and required actions left with no
+// keys keep their defaults. If the file doesn't exist, the default bindings
+// are returned without an error.
+func LoadKeymap(path string) (Keymap, error) {
+	k := DefaultKeymap()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return k, nil
+		}
+		return k, err
+	}
+	loaded := map[string]map[string][]string{}
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return k, fmt.Errorf("invalid key bindings file %q: %w", path, err)
+	}
+	for ctx, b := range loaded {
+		if k[ctx] == nil {
+			log.Printf("game::LoadKeymap(): Ignoring unknown context %q", ctx)
+			continue
+		}
+		for name, keys := range b {
+			a, ok := ParseAction(name)
+			if !ok {
+				log.Printf("game::LoadKeymap(): Ignoring unknown action %q in context %q", name, ctx)
+				continue
+			}
+			if len(keys) == 0 && Required(ctx, a) {
+				log.Printf("game::LoadKeymap(): Keeping the default keys of %s in context %q", a, ctx)
+				continue
+			}
+			k[ctx][a] = keys
+		}
+	}
+	return k, nil
+}
+
+// Save writes the key bindings to the file at the given path, creating the
+// directory holding it if needed.
+func (k Keymap) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// SaveKeymap writes the current key bindings to KeymapPath, if it is set.
+func (a *Application) SaveKeymap() {
+	if a.KeymapPath == "" {
+		return
+	}
+	if err := a.Keymap.Save(a.KeymapPath); err != nil {
+		log.Printf("game.Application::SaveKeymap(): Failed to save key bindings: %v", err)
+	}
+}
+
+// KeyCapture is implemented by menus which wait for the player to press a
+// key.
+//
+// Drivers should pass the name of the next key pressed to SetKey and then
+// send ActionMenuSelect, rather than translating the key with the keymap.
+type KeyCapture interface {
+	SetKey(string)
+}
+
+// KeyCaptureMenu is a menu which binds the next key pressed to an action.
+type KeyCaptureMenu struct {
+	StaticMenu
+	key string
+}
+
+// SetKey sets the key which will be bound when the menu is selected.
+func (k *KeyCaptureMenu) SetKey(key string) {
+	k.key = key
+}
+
+// HandleAction binds the captured key when the menu is selected.
+func (k *KeyCaptureMenu) HandleAction(a Action, app *Application) RenderRequest {
+	switch a {
+	case ActionMenuSelect:
+		if k.key != "" {
+			app.Keymap.Bind(app.keyContext, app.keyAction, k.key)
+		}
+		app.PopMenu()
+		return RenderFull
+	case ActionMenuClose:
+		app.PopMenu()
+		return RenderFull
+	}
+	return RenderNoChange
+}
+
+// NewKeyCaptureMenu returns a new KeyCaptureMenu instance, which binds the
+// next key pressed to the action being edited.
+func NewKeyCaptureMenu() *KeyCaptureMenu {
+	m := &KeyCaptureMenu{}
+	m.ID = KeyCaptureMenuID
+	m.Title = "Press a Key"
+	m.OnStart = func(app *Application) {
+		m.key = ""
+		m.Options = []string{
+			fmt.Sprintf("Press the key to bind to %s, or Escape to cancel.", app.keyAction),
+		}
+	}
+	return m
+}
+
+// NewKeyContextsMenu returns a new StaticMenu instance listing the key
+// binding contexts, along with any conflicting bindings.
+//
+// The key bindings are saved when the menu is closed.
+func NewKeyContextsMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    KeyContextsMenuID,
+		Title: "Key Bindings",
+	}
+	var refresh func(*Application)
+	refresh = func(app *Application) {
+		m.Options = make([]string, 0, len(KeyContexts)+2)
+		m.Colors = make([]color.Enum, 0, cap(m.Options))
+		m.Actions = make([]func(*Application) RenderRequest, 0, cap(m.Options))
+		for _, ctx := range KeyContexts {
+			ctx := ctx
+			m.Options = append(m.Options, keyContextNames[ctx])
+			m.Colors = append(m.Colors, color.BrightGray)
+			m.Actions = append(m.Actions, func(app *Application) RenderRequest {
+				app.keyContext = ctx
+				app.PushMenu(KeyBindingsMenuID)
+				return RenderFull
+			})
+		}
+		m.Options = append(m.Options, "Reset to Defaults")
+		m.Colors = append(m.Colors, color.BrightGray)
+		m.Actions = append(m.Actions, func(app *Application) RenderRequest {
+			app.Keymap = DefaultKeymap()
+			refresh(app)
+			return RenderFull
+		})
+		for _, c := range app.Keymap.Conflicts() {
+			m.Options = append(m.Options, "Conflict: "+c.Error())
+			m.Colors = append(m.Colors, color.BrightRed)
+			m.Actions = append(m.Actions, nil)
+		}
+	}
+	m.OnStart = refresh
+	m.OnResume = refresh
+	m.OnStop = func(app *Application) {
+		app.SaveKeymap()
+	}
+	return m
+}
+
+// NewKeyBindingsMenu returns a new StaticMenu instance listing the key
+// bindings of the context being edited.
+//
+// Selecting an action waits for a key to bind to it, while the left action
+// removes all of its keys unless the action is required. Actions with
+// conflicting keys are shown in red.
+func NewKeyBindingsMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID: KeyBindingsMenuID,
+	}
+	var refresh func(*Application)
+	refresh = func(app *Application) {
+		m.Title = keyContextNames[app.keyContext] + " Keys"
+		conflicted := map[Action]bool{}
+		for _, c := range app.Keymap.Conflicts() {
+			if c.Context != app.keyContext {
+				continue
+			}
+			for _, a := range c.Actions {
+				conflicted[a] = true
+			}
+		}
+
+		b := app.Keymap[app.keyContext]
+		actions := DefaultKeymap()[app.keyContext].Actions()
+		m.Options = make([]string, len(actions))
+		m.Colors = make([]color.Enum, len(actions))
+		m.Actions = make([]func(*Application) RenderRequest, len(actions))
+		m.Adjust = make([]func(*Application, int) RenderRequest, len(actions))
+		for i, a := range actions {
+			a := a
+			m.Options[i] = fmt.Sprintf("%-16s %s", a, strings.Join(b[a], " "))
+			m.Colors[i] = color.BrightGray
+			if conflicted[a] {
+				m.Colors[i] = color.BrightRed
+			}
+			m.Actions[i] = func(app *Application) RenderRequest {
+				app.keyAction = a
+				app.PushMenu(KeyCaptureMenuID)
+				return RenderFull
+			}
+			m.Adjust[i] = func(app *Application, delta int) RenderRequest {
+				if delta < 0 {
+					app.Keymap.Unbind(app.keyContext, a)
+					refresh(app)
+				}
+				return RenderFull
+			}
+		}
+	}
+	m.OnStart = refresh
+	m.OnResume = refresh
+	return m
+}
+
+// without returns keys with every occurrence of key removed.
+func without(keys []string, key string) []string {
+	out := keys[:0]
+	for _, k := range keys {
+		if k != key {
+			out = append(out, k)
+		}
+	}
+	return out
+}
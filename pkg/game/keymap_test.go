@@ -0,0 +1,140 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeymap(t *testing.T) {
+	t.Parallel()
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+		k := DefaultKeymap()
+		assert.Empty(t, k.Conflicts())
+		assert.Equal(t, ActionWait, k.Lookup(KeyContextGame, "."))
+		assert.Equal(t, ActionMenuSelect, k.Lookup(KeyContextLook, "."))
+		assert.Equal(t, ActionMenuDown, k.Lookup(KeyContextMenu, "j"))
+		assert.Equal(t, ActionNone, k.Lookup(KeyContextMenu, "."))
+		assert.Equal(t, ActionNone, k.Lookup("unknown", "."))
+	})
+	t.Run("bind", func(t *testing.T) {
+		t.Parallel()
+		k := DefaultKeymap()
+		k.Bind(KeyContextGame, ActionWait, "k")
+		assert.Equal(t, ActionWait, k.Lookup(KeyContextGame, "k"))
+		assert.Equal(t, []string{"8", "Up"}, k[KeyContextGame][ActionMoveNorth])
+		assert.Equal(t, ActionMoveNorth, k.Lookup(KeyContextLook, "k"))
+		assert.Empty(t, k.Conflicts())
+
+		k.Unbind(KeyContextGame, ActionWait)
+		assert.Equal(t, ActionNone, k.Lookup(KeyContextGame, "."))
+	})
+	t.Run("conflicts", func(t *testing.T) {
+		t.Parallel()
+		k := DefaultKeymap()
+		k[KeyContextMenu][ActionMenuClose] = []string{"Esc", "j"}
+		conflicts := k.Conflicts()
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, Conflict{
+			Context: KeyContextMenu,
+			Key:     "j",
+			Actions: []Action{ActionMenuDown, ActionMenuClose},
+		}, conflicts[0])
+		assert.Equal(t, `menu: "j" is bound to menu-down, menu-close`, conflicts[0].Error())
+		assert.Equal(t, ActionMenuDown, k.Lookup(KeyContextMenu, "j"))
+	})
+	t.Run("load", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "keys.json")
+		k, err := LoadKeymap(path)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultKeymap(), k)
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"game": {"wait": ["w"]}, "other": {"quit": ["q"]}}`), 0o644))
+		k, err = LoadKeymap(path)
+		require.NoError(t, err)
+		assert.Equal(t, ActionWait, k.Lookup(KeyContextGame, "w"))
+		assert.Equal(t, ActionNone, k.Lookup(KeyContextGame, "."))
+		assert.Equal(t, ActionInventory, k.Lookup(KeyContextGame, "i"))
+		assert.NotContains(t, k, "other")
+
+		// Unknown actions are skipped, and required actions keep their keys
+		data := `{"game": {"dance": ["d"], "wait": ["w"]}, "menu": {"menu-select": []}}`
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		k, err = LoadKeymap(path)
+		require.NoError(t, err)
+		assert.Equal(t, ActionWait, k.Lookup(KeyContextGame, "w"))
+		assert.Equal(t, ActionMenuSelect, k.Lookup(KeyContextMenu, "Enter"))
+
+		require.NoError(t, os.WriteFile(path, []byte(`{"game": []}`), 0o644))
+		_, err = LoadKeymap(path)
+		assert.Error(t, err)
+	})
+	t.Run("required", func(t *testing.T) {
+		t.Parallel()
+		k := DefaultKeymap()
+		for _, a := range []Action{ActionMenuSelect, ActionMenuClose, ActionQuit} {
+			assert.False(t, k.Unbind(KeyContextMenu, a), "%s", a)
+			assert.NotEmpty(t, k[KeyContextMenu][a], "%s", a)
+		}
+		assert.False(t, k.Bind(KeyContextMenu, ActionMenuDown, "Enter"))
+		assert.Equal(t, ActionMenuSelect, k.Lookup(KeyContextMenu, "Enter"))
+
+		// Once the action has another key, the first may be taken
+		assert.True(t, k.Bind(KeyContextMenu, ActionMenuSelect, "Space"))
+		assert.True(t, k.Bind(KeyContextMenu, ActionMenuDown, "Enter"))
+		assert.Equal(t, []string{"Space"}, k[KeyContextMenu][ActionMenuSelect])
+
+		// Only the menu actions of the menu context are required
+		assert.True(t, k.Unbind(KeyContextGame, ActionQuit))
+		assert.True(t, k.Unbind(KeyContextMenu, ActionMenuUp))
+	})
+	t.Run("save", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "grogue", "keys.json")
+		k := DefaultKeymap()
+		k.Bind(KeyContextMenu, ActionMenuSelect, "Space")
+		require.NoError(t, k.Save(path))
+		loaded, err := LoadKeymap(path)
+		require.NoError(t, err)
+		assert.Equal(t, k, loaded)
+	})
+	t.Run("editor", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.KeymapPath = filepath.Join(t.TempDir(), "keys.json")
+		app.PushMenu(OptionsMenuID)
+		app.GetMenu().SetOption(4)
+		app.Update(ActionMenuSelect)
+		require.Equal(t, KeyContextsMenuID, app.GetMenu().GetID())
+		app.Update(ActionMenuSelect)
+		require.Equal(t, KeyBindingsMenuID, app.GetMenu().GetID())
+
+		actions := DefaultKeymap()[KeyContextGame].Actions()
+		for i, a := range actions {
+			if a == ActionWait {
+				app.GetMenu().SetOption(i)
+			}
+		}
+		app.Update(ActionMenuSelect)
+		require.Equal(t, KeyCaptureMenuID, app.GetMenu().GetID())
+		app.GetMenu().(KeyCapture).SetKey("w")
+		app.Update(ActionMenuSelect)
+		require.Equal(t, KeyBindingsMenuID, app.GetMenu().GetID())
+		assert.Equal(t, ActionWait, app.Keymap.Lookup(KeyContextGame, "w"))
+		assert.Contains(t, app.GetMenu().GetOptions()[app.GetMenu().GetOption()], ". 5 w")
+
+		app.Update(ActionMenuLeft)
+		assert.Equal(t, ActionNone, app.Keymap.Lookup(KeyContextGame, "w"))
+
+		app.Update(ActionMenuClose)
+		app.Update(ActionMenuClose)
+		loaded, err := LoadKeymap(app.KeymapPath)
+		require.NoError(t, err)
+		assert.Equal(t, app.Keymap, loaded)
+	})
+}
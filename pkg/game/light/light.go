@@ -0,0 +1,96 @@
+package light
+
+import (
+	"math"
+
+	"github.com/tvarney/grogue/pkg/game/fov"
+)
+
+// Level is the amount of light on a tile.
+//
+// Light levels range from 0 (total darkness) to Max (full sunlight). Light
+// sources lose one level of light per tile of distance from the source.
+type Level uint8
+
+// Max is the maximum light level.
+const Max Level = 15
+
+// Map holds the light levels of a rectangular region of a single z-level.
+//
+// Positions given to the map are world-space (x,y) values; positions outside
+// of the region of the map are always dark.
+type Map struct {
+	X      int
+	Y      int
+	Z      int
+	Width  int
+	Length int
+
+	levels []Level
+}
+
+// NewMap returns a new, fully dark Map covering the given region.
+func NewMap(x, y, z, width, length int) *Map {
+	return &Map{
+		X:      x,
+		Y:      y,
+		Z:      z,
+		Width:  width,
+		Length: length,
+		levels: make([]Level, width*length),
+	}
+}
+
+func (m *Map) index(x, y int) int {
+	x -= m.X
+	y -= m.Y
+	if x < 0 || x >= m.Width || y < 0 || y >= m.Length {
+		return -1
+	}
+	return y*m.Width + x
+}
+
+// Get returns the light level at (x,y).
+func (m *Map) Get(x, y int) Level {
+	if m == nil {
+		return 0
+	}
+	idx := m.index(x, y)
+	if idx < 0 {
+		return 0
+	}
+	return m.levels[idx]
+}
+
+// Add lights the tile at (x,y) to at least the given level.
+//
+// Light from multiple sources does not accumulate; the brightest source
+// determines the level of the tile.
+func (m *Map) Add(x, y int, l Level) {
+	idx := m.index(x, y)
+	if idx < 0 {
+		return
+	}
+	if l > Max {
+		l = Max
+	}
+	if m.levels[idx] < l {
+		m.levels[idx] = l
+	}
+}
+
+// Cast adds the light from a source of the given level at (x,y).
+//
+// The light is blocked by any tile which blocks sight in the given fov.Map.
+func (m *Map) Cast(fm fov.Map, x, y int, l Level) {
+	if l == 0 {
+		return
+	}
+	fov.Compute(fm, x, y, int(l), func(tx, ty int) {
+		dx, dy := float64(tx-x), float64(ty-y)
+		dist := Level(math.Round(math.Sqrt(dx*dx + dy*dy)))
+		if dist < l {
+			m.Add(tx, ty, l-dist)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type grid []string
+
+func (g grid) BlocksSight(x, y int) bool {
+	if y < 0 || y >= len(g) || x < 0 || x >= len(g[y]) {
+		return true
+	}
+	return g[y][x] == '#'
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+	t.Run("add", func(t *testing.T) {
+		t.Parallel()
+		m := NewMap(10, 20, 0, 4, 4)
+		assert.Equal(t, Level(0), m.Get(11, 21), "dark")
+
+		m.Add(11, 21, 5)
+		assert.Equal(t, Level(5), m.Get(11, 21))
+		m.Add(11, 21, 3)
+		assert.Equal(t, Level(5), m.Get(11, 21), "brightest source")
+		m.Add(11, 21, Max+5)
+		assert.Equal(t, Max, m.Get(11, 21), "clamped to max")
+
+		m.Add(9, 20, 5)
+		assert.Equal(t, Level(0), m.Get(9, 20), "outside of map")
+		assert.Equal(t, Level(0), (*Map)(nil).Get(11, 21), "nil map")
+	})
+	t.Run("falloff", func(t *testing.T) {
+		t.Parallel()
+		g := grid{
+			".........",
+			".........",
+			".........",
+			".........",
+			".........",
+		}
+		m := NewMap(0, 0, 0, 9, 5)
+		m.Cast(g, 4, 2, 4)
+		assert.Equal(t, Level(4), m.Get(4, 2), "source")
+		assert.Equal(t, Level(3), m.Get(5, 2))
+		assert.Equal(t, Level(2), m.Get(6, 2))
+		assert.Equal(t, Level(1), m.Get(7, 2))
+		assert.Equal(t, Level(0), m.Get(8, 2), "outside of radius")
+		assert.Equal(t, Level(3), m.Get(5, 1), "diagonal")
+		assert.Equal(t, Level(1), m.Get(6, 4), "diagonal")
+
+		m.Cast(g, 4, 2, 0)
+		assert.Equal(t, Level(4), m.Get(4, 2), "unlit source")
+	})
+	t.Run("occlusion", func(t *testing.T) {
+		t.Parallel()
+		g := grid{
+			".......",
+			".......",
+			"...#...",
+			".......",
+			".......",
+		}
+		m := NewMap(0, 0, 0, 7, 5)
+		m.Cast(g, 3, 4, 6)
+		assert.Equal(t, Level(4), m.Get(3, 2), "wall is lit")
+		assert.Equal(t, Level(0), m.Get(3, 1), "behind wall")
+		assert.Equal(t, Level(0), m.Get(3, 0), "behind wall")
+		assert.Equal(t, Level(3), m.Get(0, 4))
+	})
+}
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/light"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+// DefaultPlayerLight is the level of the light source the player carries.
+const DefaultPlayerLight light.Level = 6
+
+// UpdateLight recalculates the light map for the z-level the player is on.
+//
+// Tiles which are open to the sky are fully lit. Tiles containing materials
+// which give off light, and the light source the player carries, light the
+// tiles around them.
+func (g *Game) UpdateLight() {
+	z := g.Player.Z
+	bx, by, bw, bl := g.Bounds()
+	m := light.NewMap(bx, by, z, bw, bl)
+	fm := viewMap{game: g, z: z}
+
+	for _, cc := range g.ChunkCoordsList() {
+		c := g.Chunk(cc)
+		if c == nil {
+			continue
+		}
+		for y := 0; y < chunk.Length; y++ {
+			for x := 0; x < chunk.Width; x++ {
+				gx, gy := cc.X*chunk.Width+x, cc.Y*chunk.Length+y
+				if c.OpenSky(x, y, z) {
+					m.Add(gx, gy, light.Max)
+				}
+				if l := g.emittedLight(c.Get(x, y, z)); l > 0 {
+					m.Cast(fm, gx, gy, l)
+				}
+			}
+		}
+	}
+
+	px, py := g.Player.Global()
+	m.Cast(fm, px, py, g.PlayerLight)
+	g.Light = m
+}
+
+// emittedLight returns the level of light the given tile gives off.
+func (g *Game) emittedLight(t *tile.State) light.Level {
+	l := uint8(0)
+	if t.Liquid > 0 {
+		l = g.Materials[t.LiquidMat].Liquid.Light
+	}
+	if t.Block.Definition != tile.BlockEmpty {
+		if bl := g.Materials[t.Block.Material].Solid.Light; bl > l {
+			l = bl
+		}
+	}
+	return light.Level(l)
+}
+
+// LightLevel returns the light level of the tile at the given coordinates.
+//
+// Light is only tracked for the z-level the player is on; tiles below that
+// level share the light level of the tile above them.
+func (g *Game) LightLevel(c Coords) light.Level {
+	if g.Light == nil || c.Z > g.Light.Z {
+		return 0
+	}
+	x, y := c.Global()
+	return g.Light.Get(x, y)
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/light"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+func TestUpdateLight(t *testing.T) {
+	t.Parallel()
+	t.Run("open sky", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.UpdateLight()
+		assert.Equal(t, light.Max, g.LightLevel(g.Player))
+		assert.Equal(t, light.Max, g.LightLevel(g.Player.Offset(12, -9, 0)))
+		assert.Equal(t, light.Max, g.LightLevel(g.Player.Offset(0, 0, -1)), "below the player")
+		assert.Equal(t, light.Level(0), g.LightLevel(g.Player.Offset(0, 0, 1)), "above the player")
+	})
+	t.Run("underground", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		stone := g.Generator.Stone[0]
+
+		// A corridor running east from the player, under the surface
+		g.Player.Z = chunk.SurfaceLevel - 1
+		for x := 0; x < 8; x++ {
+			*g.Tile(g.Player.Offset(x, 0, 0)) = tile.State{
+				Block: tile.Part{Definition: tile.BlockEmpty, Material: g.Generator.Air},
+				Floor: tile.Part{Definition: tile.FloorStone, Material: stone},
+			}
+		}
+		g.UpdateLight()
+		assert.Equal(t, DefaultPlayerLight, g.LightLevel(g.Player))
+		assert.Equal(t, DefaultPlayerLight-2, g.LightLevel(g.Player.Offset(2, 0, 0)))
+		assert.Equal(t, light.Level(0), g.LightLevel(g.Player.Offset(7, 0, 0)), "out of reach")
+		assert.Equal(t, light.Level(0), g.LightLevel(g.Player.Offset(2, 2, 0)), "inside the rock")
+	})
+}
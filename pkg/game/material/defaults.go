@@ -28,6 +28,7 @@ func DefaultMaterials() []*Material {
 				Adjective: "air",
 				Color:     color.BrightWhite,
 			},
+			Density: 0.0013,
 		},
 		{
 			Type: Stone,
@@ -46,6 +47,8 @@ func DefaultMaterials() []*Material {
 				Adjective: "steam",
 				Color:     color.White,
 			},
+			Density:  0.92,
+			Hardness: 1.5,
 		},
 		{
 			Type: Stone,
@@ -58,12 +61,16 @@ func DefaultMaterials() []*Material {
 				Name:      "magma",
 				Adjective: "magma",
 				Color:     color.BrightOrange,
+				Light:     12,
 			},
 			Gas: State{
 				Name:      "vaporized bedrock",
 				Adjective: "vaporized bedrock",
 				Color:     color.BrightOrange,
+				Light:     8,
 			},
+			Density:  3.0,
+			Hardness: 7.0,
 		},
 		{
 			Type: Stone,
@@ -76,12 +83,16 @@ func DefaultMaterials() []*Material {
 				Name:      "lava",
 				Adjective: "lava",
 				Color:     color.Orange,
+				Light:     10,
 			},
 			Gas: State{
 				Name:      "vaporized stone",
 				Adjective: "vaporized stone",
 				Color:     color.BrightOrange,
+				Light:     8,
 			},
+			Density:  2.6,
+			Hardness: 6.0,
 		},
 		{
 			Type: Soil,
@@ -100,6 +111,134 @@ func DefaultMaterials() []*Material {
 				Adjective: "dirt",
 				Color:     color.Brown,
 			},
+			Density:  1.5,
+			Hardness: 1.0,
+		},
+		{
+			Type: Metal,
+			Solid: State{
+				Name:      "iron",
+				Adjective: "iron",
+				Color:     color.BrightGray,
+			},
+			Liquid: State{
+				Name:      "molten iron",
+				Adjective: "molten iron",
+				Color:     color.BrightOrange,
+				Light:     10,
+			},
+			Gas: State{
+				Name:      "boiling iron",
+				Adjective: "boiling iron",
+				Color:     color.BrightOrange,
+				Light:     8,
+			},
+			Density:  7.9,
+			Hardness: 4.5,
+		},
+		{
+			Type: Metal,
+			Solid: State{
+				Name:      "copper",
+				Adjective: "copper",
+				Color:     color.BrightOrange,
+			},
+			Liquid: State{
+				Name:      "molten copper",
+				Adjective: "molten copper",
+				Color:     color.BrightOrange,
+				Light:     10,
+			},
+			Gas: State{
+				Name:      "boiling copper",
+				Adjective: "boiling copper",
+				Color:     color.BrightOrange,
+				Light:     8,
+			},
+			Density:  8.9,
+			Hardness: 3.0,
+		},
+		{
+			Type: Wood,
+			Solid: State{
+				Name:      "oak",
+				Adjective: "oaken",
+				Color:     color.BrightBrown,
+			},
+			Liquid: State{
+				Name:      "oak",
+				Adjective: "oaken",
+				Color:     color.BrightBrown,
+			},
+			Gas: State{
+				Name:      "oak",
+				Adjective: "oaken",
+				Color:     color.BrightBrown,
+			},
+			Density:  0.75,
+			Hardness: 2.5,
+		},
+		{
+			Type: Gem,
+			Solid: State{
+				Name:      "quartz",
+				Adjective: "quartz",
+				Color:     color.White,
+			},
+			Liquid: State{
+				Name:      "molten quartz",
+				Adjective: "molten quartz",
+				Color:     color.BrightOrange,
+				Light:     10,
+			},
+			Gas: State{
+				Name:      "vaporized quartz",
+				Adjective: "vaporized quartz",
+				Color:     color.BrightOrange,
+				Light:     8,
+			},
+			Density:  2.65,
+			Hardness: 7.0,
+		},
+		{
+			Type: Bone,
+			Solid: State{
+				Name:      "bone",
+				Adjective: "bone",
+				Color:     color.BrightWhite,
+			},
+			Liquid: State{
+				Name:      "bone",
+				Adjective: "bone",
+				Color:     color.BrightWhite,
+			},
+			Gas: State{
+				Name:      "bone",
+				Adjective: "bone",
+				Color:     color.BrightWhite,
+			},
+			Density:  1.9,
+			Hardness: 3.5,
+		},
+		{
+			Type: Flesh,
+			Solid: State{
+				Name:      "flesh",
+				Adjective: "fleshy",
+				Color:     color.Pink,
+			},
+			Liquid: State{
+				Name:      "blood",
+				Adjective: "bloody",
+				Color:     color.Red,
+			},
+			Gas: State{
+				Name:      "flesh",
+				Adjective: "fleshy",
+				Color:     color.Pink,
+			},
+			Density:  1.05,
+			Hardness: 0.5,
 		},
 	}
 }
@@ -24,8 +24,34 @@ const (
 	Bone
 	Flesh
 	Misc
+
+	typeCount
 )
 
+var typeNames = [typeCount]string{
+	"stone", "metal", "soil", "sand", "glass", "gem", "wood", "bone", "flesh",
+	"misc",
+}
+
+// String returns the name of the material type.
+func (t Type) String() string {
+	if t >= typeCount {
+		return "unknown"
+	}
+	return typeNames[t]
+}
+
+// ParseType returns the material type with the given name, as returned by
+// String.
+func ParseType(name string) (Type, bool) {
+	for t := Type(0); t < typeCount; t++ {
+		if typeNames[t] == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 // State is a set of values for a material which depend on the physical state
 // the material is in.
 //
@@ -35,6 +61,9 @@ type State struct {
 	Name      string
 	Adjective string
 	Color     color.Enum
+
+	// Light is the level of light the material gives off in this state.
+	Light uint8
 }
 
 // Material is the definition of a material for the game.
@@ -43,4 +72,11 @@ type Material struct {
 	Solid  State
 	Liquid State
 	Gas    State
+
+	// Density is the density of the solid material, in kg/L.
+	Density float64
+
+	// Hardness is the hardness of the solid material, on a scale from 0 to
+	// 10 loosely following the Mohs scale.
+	Hardness float64
 }
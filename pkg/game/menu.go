@@ -3,11 +3,12 @@ package game
 import (
 	"log"
 
-	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/color"
 )
 
 const (
-	MainMenuID = "main-menu"
+	MainMenuID       = "main-menu"
+	MessageLogMenuID = "message-log"
 )
 
 // Menu defines how game drivers may interact with menus in the game.
@@ -24,6 +25,13 @@ type Menu interface {
 	HandleAction(Action, *Application) RenderRequest
 }
 
+// ColoredMenu is implemented by menus which set the color of their options.
+type ColoredMenu interface {
+	// GetOptionColor returns the color of the option, or false if the option
+	// should be displayed normally.
+	GetOptionColor(int) (color.Enum, bool)
+}
+
 // StaticMenu is a menu which is fully defined statically.
 type StaticMenu struct {
 	ID       string
@@ -33,9 +41,15 @@ type StaticMenu struct {
 	OnPause  func(*Application)
 	OnResume func(*Application)
 	Options  []string
+	Colors   []color.Enum
 	Actions  []func(*Application) RenderRequest
 	Cursor   int
 
+	// Adjust holds callbacks for each option which handle ActionMenuLeft
+	// (with a delta of -1) and ActionMenuRight (with a delta of 1), for
+	// options which have a value that may be changed.
+	Adjust []func(app *Application, delta int) RenderRequest
+
 	instances int
 }
 
@@ -114,6 +128,14 @@ func (s *StaticMenu) GetOption() int {
 	return s.Cursor
 }
 
+// GetOptionColor returns the color of the option, if colors are set.
+func (s *StaticMenu) GetOptionColor(idx int) (color.Enum, bool) {
+	if idx < 0 || idx >= len(s.Colors) {
+		return 0, false
+	}
+	return s.Colors[idx], true
+}
+
 // SetOption sets the currently focused option.
 func (s *StaticMenu) SetOption(idx int) {
 	if idx < 0 {
@@ -137,6 +159,15 @@ func (s *StaticMenu) HandleAction(a Action, app *Application) RenderRequest {
 			s.Cursor++
 			return RenderIncremental
 		}
+	case ActionMenuLeft, ActionMenuRight:
+		if s.Cursor < 0 || s.Cursor >= len(s.Adjust) || s.Adjust[s.Cursor] == nil {
+			return RenderNoChange
+		}
+		delta := 1
+		if a == ActionMenuLeft {
+			delta = -1
+		}
+		return s.Adjust[s.Cursor](app, delta)
 	case ActionMenuSelect:
 		if s.Cursor < 0 || s.Cursor >= len(s.Actions) {
 			return RenderNoChange
@@ -169,24 +200,14 @@ func NewMainMenu() *StaticMenu {
 		Actions: []func(*Application) RenderRequest{
 			func(app *Application) RenderRequest {
 				log.Printf("game.StaticMenu::Actions[0]: Selected New Game")
-				app.InGame = true
-				app.Game.ActiveChunks[0] = app.Game.Generator.Generate(-1, -1)
-				app.Game.ActiveChunks[1] = app.Game.Generator.Generate(0, -1)
-				app.Game.ActiveChunks[2] = app.Game.Generator.Generate(1, -1)
-				app.Game.ActiveChunks[3] = app.Game.Generator.Generate(-1, 0)
-				app.Game.ActiveChunks[4] = app.Game.Generator.Generate(0, 0)
-				app.Game.ActiveChunks[5] = app.Game.Generator.Generate(1, 0)
-				app.Game.ActiveChunks[6] = app.Game.Generator.Generate(-1, 1)
-				app.Game.ActiveChunks[7] = app.Game.Generator.Generate(0, 1)
-				app.Game.ActiveChunks[8] = app.Game.Generator.Generate(1, 1)
-				app.Game.Player.X = chunk.Width / 2
-				app.Game.Player.Y = chunk.Length / 2
-				app.Game.Player.Z = 33
-				app.PopMenu()
+				app.PushMenu(CharacterNameMenuID)
 				return RenderFull
 			},
 			nil,
-			nil,
+			func(app *Application) RenderRequest {
+				app.PushMenu(OptionsMenuID)
+				return RenderFull
+			},
 			nil,
 			func(app *Application) RenderRequest {
 				app.Quit()
@@ -195,3 +216,26 @@ func NewMainMenu() *StaticMenu {
 		},
 	}
 }
+
+// NewMessageLogMenu returns a new StaticMenu instance for browsing the message
+// log.
+//
+// The options of the menu are filled in from the message log each time the
+// menu is shown, with the most recent message selected.
+func NewMessageLogMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    MessageLogMenuID,
+		Title: "Messages",
+	}
+	m.OnStart = func(app *Application) {
+		msgs := app.Game.Messages.All()
+		m.Options = make([]string, len(msgs))
+		m.Colors = make([]color.Enum, len(msgs))
+		for i, msg := range msgs {
+			m.Options[i] = msg.String()
+			m.Colors[i] = msg.Severity.Color()
+		}
+		m.SetOption(len(msgs) - 1)
+	}
+	return m
+}
@@ -0,0 +1,101 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/tvarney/grogue/pkg/game/color"
+)
+
+// Severity is an enumeration of message importance levels.
+type Severity uint8
+
+const (
+	Info Severity = iota
+	Good
+	Warning
+	Danger
+)
+
+// Color returns the color messages of the severity are displayed with.
+func (s Severity) Color() color.Enum {
+	switch s {
+	case Good:
+		return color.Green
+	case Warning:
+		return color.Yellow
+	case Danger:
+		return color.BrightRed
+	}
+	return color.BrightGray
+}
+
+// Message is a single entry in the message log.
+type Message struct {
+	Text     string
+	Severity Severity
+
+	// Count is the number of times the message was repeated.
+	Count int
+}
+
+// String returns the text of the message, along with the repeat count if the
+// message was repeated.
+func (m Message) String() string {
+	if m.Count > 1 {
+		return fmt.Sprintf("%s x%d", m.Text, m.Count)
+	}
+	return m.Text
+}
+
+// Log is a bounded log of game messages.
+//
+// A message which is identical to the most recent message is collapsed into
+// it, incrementing its count. Once the log holds more than its limit, the
+// oldest messages are discarded.
+type Log struct {
+	messages []Message
+	limit    int
+}
+
+// NewLog returns a new Log holding at most limit messages.
+func NewLog(limit int) *Log {
+	return &Log{limit: limit}
+}
+
+// Add adds a message to the log.
+func (l *Log) Add(sev Severity, text string) {
+	if n := len(l.messages); n > 0 {
+		last := &l.messages[n-1]
+		if last.Text == text && last.Severity == sev {
+			last.Count++
+			return
+		}
+	}
+	l.messages = append(l.messages, Message{Text: text, Severity: sev, Count: 1})
+	if l.limit > 0 && len(l.messages) > l.limit {
+		l.messages = append(l.messages[:0], l.messages[len(l.messages)-l.limit:]...)
+	}
+}
+
+// Addf adds a formatted message to the log.
+func (l *Log) Addf(sev Severity, format string, args ...interface{}) {
+	l.Add(sev, fmt.Sprintf(format, args...))
+}
+
+// Len returns the number of messages in the log.
+func (l *Log) Len() int {
+	return len(l.messages)
+}
+
+// All returns every message in the log, oldest first.
+func (l *Log) All() []Message {
+	return l.messages
+}
+
+// Last returns the n most recent messages in the log, oldest first.
+func (l *Log) Last(n int) []Message {
+	if n > len(l.messages) {
+		n = len(l.messages)
+	}
+	return l.messages[len(l.messages)-n:]
+}
@@ -0,0 +1,37 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+	t.Run("collapse", func(t *testing.T) {
+		t.Parallel()
+		l := NewLog(10)
+		l.Add(Info, "You dig.")
+		l.Add(Info, "You dig.")
+		l.Add(Info, "You dig.")
+		require.Equal(t, 1, l.Len())
+		assert.Equal(t, "You dig. x3", l.Last(1)[0].String())
+
+		l.Add(Warning, "You dig.")
+		l.Add(Info, "You dig.")
+		require.Equal(t, 3, l.Len())
+		assert.Equal(t, "You dig.", l.Last(1)[0].String())
+	})
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+		l := NewLog(2)
+		l.Add(Info, "one")
+		l.Add(Info, "two")
+		l.Add(Info, "three")
+		require.Equal(t, 2, l.Len())
+		assert.Equal(t, "two", l.All()[0].Text)
+		assert.Equal(t, "three", l.All()[1].Text)
+		assert.Len(t, l.Last(5), 2)
+	})
+}
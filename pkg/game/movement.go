@@ -0,0 +1,132 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+// Step is a movement from a tile to one of its neighbors.
+type Step struct {
+	DX int
+	DY int
+	DZ int
+}
+
+// Steps is the set of every movement which may be made from a tile.
+//
+// This includes plain horizontal and diagonal movement, climbing or
+// descending ramps, and climbing or descending stairs.
+var Steps = []Step{
+	{DX: 0, DY: -1}, {DX: 0, DY: 1}, {DX: -1, DY: 0}, {DX: 1, DY: 0},
+	{DX: 1, DY: -1}, {DX: -1, DY: -1}, {DX: 1, DY: 1}, {DX: -1, DY: 1},
+	{DX: 0, DY: -1, DZ: 1}, {DX: 0, DY: 1, DZ: 1}, {DX: -1, DY: 0, DZ: 1}, {DX: 1, DY: 0, DZ: 1},
+	{DX: 0, DY: -1, DZ: -1}, {DX: 0, DY: 1, DZ: -1}, {DX: -1, DY: 0, DZ: -1}, {DX: 1, DY: 0, DZ: -1},
+	{DZ: 1}, {DZ: -1},
+}
+
+const (
+	// CostHorizontal is the base cost of moving to an adjacent tile.
+	CostHorizontal = 10
+	// CostDiagonal is the base cost of moving to a diagonally adjacent tile.
+	CostDiagonal = 14
+	// CostRamp is the base cost of moving up or down a ramp.
+	CostRamp = 15
+	// CostStairs is the base cost of moving up or down stairs.
+	CostStairs = 20
+	// CostLiquid is the additional cost per level of liquid depth of the tile
+	// moved into.
+	CostLiquid = 3
+)
+
+// Standable returns true if a creature could stand in the tile at c.
+//
+// A tile is standable if it is passable and the creature has something to
+// stand on; a floor, stairs, a ramp, liquid to swim in, or a solid block
+// directly below it.
+func (g *Game) Standable(c Coords) bool {
+	t := g.Tile(c)
+	if t == nil || !t.Passable() {
+		return false
+	}
+	if t.Block.Definition != tile.BlockEmpty || t.Floor.Definition != tile.FloorEmpty || t.Liquid > 0 {
+		return true
+	}
+	below := g.Tile(c.Offset(0, 0, -1))
+	return below != nil && !below.Passable()
+}
+
+// passable returns true if the tile at c exists and is passable.
+func (g *Game) passable(c Coords) bool {
+	t := g.Tile(c)
+	return t != nil && t.Passable()
+}
+
+// open returns true if the tile at c is passable and has no floor, allowing
+// movement between it and the level below.
+func (g *Game) open(c Coords) bool {
+	t := g.Tile(c)
+	return t != nil && t.Passable() && t.Floor.Definition == tile.FloorEmpty
+}
+
+// CanStep returns true if a creature standing at from may make the step s.
+//
+// Diagonal steps may not cut the corner of a solid block; both of the tiles
+// orthogonally adjacent to from in the direction of the step must be
+// passable. Depth of liquid is not considered; see PathOptions for limiting
+// movement through liquids.
+func (g *Game) CanStep(from Coords, s Step) bool {
+	to := from.Offset(s.DX, s.DY, s.DZ)
+	if s.DX == 0 && s.DY == 0 {
+		src, dst := g.Tile(from), g.Tile(to)
+		if src == nil || dst == nil {
+			return false
+		}
+		switch s.DZ {
+		case 1:
+			return src.StairsUp() && dst.StairsDown()
+		case -1:
+			return src.StairsDown() && dst.StairsUp()
+		}
+		return false
+	}
+
+	if s.DX != 0 && s.DY != 0 {
+		if s.DZ != 0 || !g.passable(from.Offset(s.DX, 0, 0)) || !g.passable(from.Offset(0, s.DY, 0)) {
+			return false
+		}
+	}
+
+	switch s.DZ {
+	case 1:
+		// Climbing a ramp; there must be room above the ramp
+		src := g.Tile(from)
+		if src == nil || src.Block.Definition != tile.BlockRamp || !g.open(from.Offset(0, 0, 1)) {
+			return false
+		}
+	case -1:
+		// Descending a ramp; there must be room above the ramp
+		dst := g.Tile(to)
+		if dst == nil || dst.Block.Definition != tile.BlockRamp || !g.open(to.Offset(0, 0, 1)) {
+			return false
+		}
+	}
+	return g.Standable(to)
+}
+
+// MoveCost returns the cost of making the step s from the tile at from.
+//
+// This does not check if the step may be made.
+func (g *Game) MoveCost(from Coords, s Step) int {
+	cost := CostHorizontal
+	switch {
+	case s.DZ != 0 && s.DX == 0 && s.DY == 0:
+		cost = CostStairs
+	case s.DZ != 0:
+		cost = CostRamp
+	case s.DX != 0 && s.DY != 0:
+		cost = CostDiagonal
+	}
+	if t := g.Tile(from.Offset(s.DX, s.DY, s.DZ)); t != nil {
+		cost += int(t.Liquid) * CostLiquid
+	}
+	return cost
+}
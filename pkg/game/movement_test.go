@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+func TestCanStep(t *testing.T) {
+	t.Parallel()
+	t.Run("diagonal", func(t *testing.T) {
+		t.Parallel()
+		northEast := Step{DX: 1, DY: -1}
+		for _, tc := range []struct {
+			name  string
+			solid []Coords
+			ok    bool
+		}{
+			{name: "open", ok: true},
+			{name: "east", solid: []Coords{{X: 1}}},
+			{name: "north", solid: []Coords{{Y: -1}}},
+			{name: "both", solid: []Coords{{X: 1}, {Y: -1}}},
+		} {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				g := newTestGame(t)
+				stone := g.Generator.Stone[0]
+				for _, c := range tc.solid {
+					*g.Tile(g.Player.Offset(c.X, c.Y, 0)) = tile.State{
+						Block: tile.Part{Definition: tile.BlockStone, Material: stone},
+						Floor: tile.Part{Definition: tile.FloorStone, Material: stone},
+					}
+				}
+				assert.Equal(t, tc.ok, g.CanStep(g.Player, northEast))
+			})
+		}
+	})
+	t.Run("ramp", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		stone := g.Generator.Stone[0]
+
+		// A ramp at the player's feet leads up onto a stone block to the east
+		ramp := g.Player
+		*g.Tile(ramp) = tile.State{
+			Block: tile.Part{Definition: tile.BlockRamp, Material: stone},
+			Floor: tile.Part{Definition: tile.FloorStone, Material: stone},
+		}
+		*g.Tile(ramp.Offset(1, 0, 0)) = tile.State{
+			Block: tile.Part{Definition: tile.BlockStone, Material: stone},
+			Floor: tile.Part{Definition: tile.FloorStone, Material: stone},
+		}
+		top := ramp.Offset(1, 0, 1)
+
+		assert.True(t, g.CanStep(ramp, Step{DX: 1, DZ: 1}))
+		assert.True(t, g.CanStep(top, Step{DX: -1, DZ: -1}))
+		// Only a ramp may be climbed or descended
+		assert.False(t, g.CanStep(ramp.Offset(0, 1, 0), Step{DX: 1, DZ: 1}))
+		assert.False(t, g.CanStep(top.Offset(0, 1, 0), Step{DX: -1, DZ: -1}))
+		// The ramp may only be climbed onto something to stand on
+		assert.False(t, g.CanStep(ramp, Step{DX: -1, DZ: 1}))
+
+		// A floor above the ramp blocks it in both directions
+		g.Tile(ramp.Offset(0, 0, 1)).Floor = tile.Part{Definition: tile.FloorStone, Material: stone}
+		assert.False(t, g.CanStep(ramp, Step{DX: 1, DZ: 1}))
+		assert.False(t, g.CanStep(top, Step{DX: -1, DZ: -1}))
+	})
+}
+
+func TestDirection(t *testing.T) {
+	t.Parallel()
+	k := DefaultKeymap()
+	for _, tc := range []struct {
+		key    string
+		dx, dy int
+	}{
+		{key: "y", dx: -1, dy: -1},
+		{key: "u", dx: 1, dy: -1},
+		{key: "b", dx: -1, dy: 1},
+		{key: "n", dx: 1, dy: 1},
+		{key: "h", dx: -1},
+		{key: "l", dx: 1},
+	} {
+		dx, dy, dz, ok := k.Lookup(KeyContextGame, tc.key).Direction()
+		assert.True(t, ok, tc.key)
+		assert.Equal(t, []int{tc.dx, tc.dy, 0}, []int{dx, dy, dz}, tc.key)
+	}
+	_, _, _, ok := ActionWait.Direction()
+	assert.False(t, ok)
+}
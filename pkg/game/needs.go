@@ -0,0 +1,75 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const (
+	// EatTicks is the number of ticks eating takes.
+	EatTicks = 5 * TurnTicks
+
+	// MealTicks is the amount hunger is reduced by for each kg of food
+	// eaten.
+	MealTicks = 200 * TurnTicks
+
+	// RestTicks is the amount fatigue is reduced by for each turn spent
+	// resting.
+	RestTicks = 5 * TurnTicks
+)
+
+// Drink has the player drink from water in or next to the tile they stand
+// in.
+func (g *Game) Drink() {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			for dz := 0; dz >= -1; dz-- {
+				t := g.Tile(g.Player.Offset(dx, dy, dz))
+				if t == nil || t.Liquid == 0 || t.LiquidMat != g.Generator.Water {
+					continue
+				}
+				g.Character.Thirst = 0
+				g.Message(message.Info, "You drink your fill.")
+				g.EndTurn(TurnTicks)
+				return
+			}
+		}
+	}
+	g.Message(message.Info, "There is no water here to drink.")
+}
+
+// Edible returns true if the item may be eaten.
+func Edible(it *item.Item) bool {
+	return it.Kind == item.Corpse
+}
+
+// Eat has the player eat a single item from the stack at index idx of their
+// inventory.
+func (g *Game) Eat(idx int) {
+	if idx < 0 || idx >= g.Inventory.Len() || !Edible(&g.Inventory.Items[idx]) {
+		g.Message(message.Info, "You can't eat that.")
+		return
+	}
+	it := g.Inventory.Remove(idx, 1)
+	g.Character.Hunger -= int64(it.Weight(g.Materials) * MealTicks)
+	if g.Character.Hunger < 0 {
+		g.Character.Hunger = 0
+	}
+	g.Message(message.Info, "You eat %s.", it.Name(g.Materials))
+	g.EndTurn(EatTicks)
+}
+
+// Rest has the player rest for a single turn, reducing their fatigue.
+//
+// This returns false if the player is fully rested.
+func (g *Game) Rest() bool {
+	if g.Character.Fatigue <= 0 {
+		return false
+	}
+	g.EndTurn(TurnTicks)
+	g.Character.Fatigue -= RestTicks + TurnTicks
+	if g.Character.Fatigue < 0 {
+		g.Character.Fatigue = 0
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/tvarney/grogue/pkg/cerr"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const (
+	CharacterNameMenuID       = "new-game-name"
+	CharacterBackgroundMenuID = "new-game-background"
+	WorldOptionsMenuID        = "new-game-world"
+	WorldSeedMenuID           = "new-game-seed"
+)
+
+const (
+	// MaxNameLength is the maximum length of a character's name.
+	MaxNameLength = 24
+
+	// ErrEmptyName is returned when validating a name with no letters.
+	ErrEmptyName = cerr.Error("the name must not be empty")
+
+	// ErrInvalidSeed is returned when validating a seed which isn't a
+	// number.
+	ErrInvalidSeed = cerr.Error("the seed must be a number")
+)
+
+// WorldSize is a named world radius which may be chosen when starting a new
+// game.
+type WorldSize struct {
+	Name   string
+	Radius int
+}
+
+// WorldSizes are the world sizes which may be chosen when starting a new
+// game.
+var WorldSizes = []WorldSize{
+	{Name: "Normal", Radius: DefaultWorldRadius},
+	{Name: "Large", Radius: 2},
+	{Name: "Huge", Radius: 3},
+}
+
+// NewGameOptions are the choices made during character creation.
+type NewGameOptions struct {
+	Name        string
+	Background  int
+	Seed        int64
+	WorldRadius int
+}
+
+// SetSeed sets the seed used to generate the world, replacing the world
+// generator and the random number generator of the game.
+func (g *Game) SetSeed(seed int64) {
+	g.Seed = seed
+	g.Generator = chunk.NewGenerator(seed, g.Materials)
+	g.Rand = rand.New(rand.NewSource(seed))
+}
+
+// StartGame starts a new game with the given options.
+//
+// A new world is generated, the player is given a new character, and all
+// menus are closed.
+func (a *Application) StartGame(opts NewGameOptions) {
+	log.Printf("game.Application::StartGame(): %q the %s, seed %d", opts.Name, a.Game.Backgrounds[opts.Background].Name, opts.Seed)
+	next := a.Game.Rand.Int63()
+
+	g := a.Game
+	bg := &g.Backgrounds[opts.Background]
+	g.SetSeed(opts.Seed)
+	g.WorldRadius = opts.WorldRadius
+	g.Messages = message.NewLog(MessageLimit)
+	g.ResetPlayer()
+	g.Character = g.NewCharacter(opts.Name, DefaultAttributes().Add(bg.Attributes))
+	g.GiveItems(bg.Items)
+	g.GenerateWorld()
+	g.PlacePlayer()
+	g.PlaceTrainingDummy()
+	g.UpdateView()
+	g.Message(message.Good, "Welcome to GRogue, %s!", opts.Name)
+	g.noticeCreatures()
+
+	// Remember the choices for the next game, but don't generate the same
+	// world again.
+	a.NewGame = opts
+	a.NewGame.Seed = next
+
+	a.InGame = true
+	for a.GetMenu() != nil {
+		a.PopMenu()
+	}
+}
+
+// NewCharacterNameMenu returns a new TextMenu instance for naming a new
+// character.
+func NewCharacterNameMenu() *TextMenu {
+	return &TextMenu{
+		ID:        CharacterNameMenuID,
+		Title:     "New Character",
+		Prompt:    "Name: ",
+		MaxLength: MaxNameLength,
+		Validate: func(name string) error {
+			if strings.TrimSpace(name) == "" {
+				return ErrEmptyName
+			}
+			return nil
+		},
+		OnStart: func(app *Application) string {
+			return app.NewGame.Name
+		},
+		OnAccept: func(app *Application, name string) RenderRequest {
+			app.NewGame.Name = strings.TrimSpace(name)
+			app.PushMenu(CharacterBackgroundMenuID)
+			return RenderFull
+		},
+	}
+}
+
+// NewWorldSeedMenu returns a new TextMenu instance for entering the seed of
+// the world of a new game.
+//
+// Leaving the seed empty picks a new random seed.
+func NewWorldSeedMenu() *TextMenu {
+	return &TextMenu{
+		ID:        WorldSeedMenuID,
+		Title:     "World Seed",
+		Prompt:    "Seed: ",
+		MaxLength: 20,
+		Allow: func(r rune) bool {
+			return r == '-' || (r >= '0' && r <= '9')
+		},
+		Validate: func(text string) error {
+			if text == "" {
+				return nil
+			}
+			if _, err := strconv.ParseInt(text, 10, 64); err != nil {
+				return ErrInvalidSeed
+			}
+			return nil
+		},
+		OnStart: func(app *Application) string {
+			return strconv.FormatInt(app.NewGame.Seed, 10)
+		},
+		OnAccept: func(app *Application, text string) RenderRequest {
+			if text == "" {
+				app.NewGame.Seed = app.Game.Rand.Int63()
+			} else {
+				app.NewGame.Seed, _ = strconv.ParseInt(text, 10, 64)
+			}
+			app.PopMenu()
+			return RenderFull
+		},
+	}
+}
+
+// NewCharacterBackgroundMenu returns a new StaticMenu instance for choosing
+// the background of a new character.
+func NewCharacterBackgroundMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    CharacterBackgroundMenuID,
+		Title: "Choose a Background",
+	}
+	m.OnStart = func(app *Application) {
+		bgs := app.Game.Backgrounds
+		m.Options = make([]string, len(bgs))
+		m.Actions = make([]func(*Application) RenderRequest, len(bgs))
+		for i := range bgs {
+			idx := i
+			m.Options[i] = fmt.Sprintf("%-8s %s (%s)", bgs[i].Name, bgs[i].Description, bgs[i].Summary())
+			m.Actions[i] = func(app *Application) RenderRequest {
+				app.NewGame.Background = idx
+				app.PushMenu(WorldOptionsMenuID)
+				return RenderFull
+			}
+		}
+		m.SetOption(app.NewGame.Background)
+	}
+	return m
+}
+
+// NewWorldOptionsMenu returns a new StaticMenu instance for choosing the seed
+// and size of the world of a new game.
+func NewWorldOptionsMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    WorldOptionsMenuID,
+		Title: "World Options",
+	}
+	refresh := func(app *Application) {
+		size := fmt.Sprintf("%d chunks", 2*app.NewGame.WorldRadius+1)
+		for _, s := range WorldSizes {
+			if s.Radius == app.NewGame.WorldRadius {
+				size = s.Name
+			}
+		}
+		m.Options = []string{
+			fmt.Sprintf("Seed: %d", app.NewGame.Seed),
+			"Size: " + size,
+			"Begin",
+		}
+	}
+	resize := func(app *Application, delta int) RenderRequest {
+		idx := 0
+		for i, s := range WorldSizes {
+			if s.Radius == app.NewGame.WorldRadius {
+				idx = i
+				break
+			}
+		}
+		app.NewGame.WorldRadius = WorldSizes[wrap(idx+delta, len(WorldSizes))].Radius
+		refresh(app)
+		return RenderFull
+	}
+	m.OnStart = refresh
+	m.OnResume = refresh
+	m.Actions = []func(*Application) RenderRequest{
+		func(app *Application) RenderRequest {
+			app.PushMenu(WorldSeedMenuID)
+			return RenderFull
+		},
+		func(app *Application) RenderRequest {
+			return resize(app, 1)
+		},
+		func(app *Application) RenderRequest {
+			app.StartGame(app.NewGame)
+			return RenderFull
+		},
+	}
+	m.Adjust = []func(*Application, int) RenderRequest{nil, resize}
+	return m
+}
+
+// wrap returns i wrapped into the range [0, n).
+func wrap(i, n int) int {
+	return ((i % n) + n) % n
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/item"
+)
+
+func TestNewGame(t *testing.T) {
+	t.Parallel()
+	t.Run("menus", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.Update(ActionMenuSelect)
+		require.Equal(t, CharacterNameMenuID, app.GetMenu().GetID())
+		entry := app.GetMenu().(TextEntry)
+		assert.Equal(t, DefaultPlayerName, entry.GetText())
+		for range DefaultPlayerName {
+			app.Update(ActionMenuBackspace)
+		}
+		app.Update(ActionMenuSelect)
+		assert.Equal(t, ErrEmptyName.Error(), entry.GetError())
+		for _, r := range "Hilde" {
+			entry.Insert(r)
+		}
+		app.Update(ActionMenuSelect)
+		require.Equal(t, CharacterBackgroundMenuID, app.GetMenu().GetID())
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuSelect)
+		require.Equal(t, WorldOptionsMenuID, app.GetMenu().GetID())
+		assert.Equal(t, 1, app.NewGame.Background)
+
+		app.Update(ActionMenuSelect)
+		require.Equal(t, WorldSeedMenuID, app.GetMenu().GetID())
+		entry = app.GetMenu().(TextEntry)
+		assert.False(t, entry.Insert('x'))
+		app.Update(ActionMenuBackspace)
+		entry.Insert('2')
+		app.Update(ActionMenuSelect)
+		require.Equal(t, WorldOptionsMenuID, app.GetMenu().GetID())
+		assert.Equal(t, int64(2), app.NewGame.Seed)
+		assert.Equal(t, "Seed: 2", app.GetMenu().GetOptions()[0])
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuRight)
+		assert.Equal(t, WorldSizes[1].Radius, app.NewGame.WorldRadius)
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuSelect)
+
+		require.True(t, app.InGame)
+		assert.Nil(t, app.GetMenu())
+		g := app.Game
+		assert.Equal(t, int64(2), g.Seed)
+		assert.Len(t, g.ActiveChunks, 25)
+		assert.Equal(t, "Hilde", g.Character.Name)
+		assert.Equal(t, AverageAttribute+2, g.Character.Attributes.Strength)
+		assert.NotEqual(t, int64(2), app.NewGame.Seed)
+	})
+	t.Run("items", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		opts := app.NewGame
+		for i, bg := range app.Game.Backgrounds {
+			if bg.Name == "Smith" {
+				opts.Background = i
+			}
+		}
+		app.StartGame(opts)
+		inv := &app.Game.Inventory
+		require.Equal(t, 3, inv.Len())
+		assert.Equal(t, item.Hammer, inv.Items[0].Kind)
+		assert.Equal(t, 3, inv.Items[1].Count)
+	})
+	t.Run("training dummy", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.StartGame(app.NewGame)
+		g := app.Game
+		dummy := speciesID(t, g, TrainingDummy)
+		found := false
+		for _, cr := range g.Creatures {
+			if cr.Species == dummy {
+				found = true
+				assert.Equal(t, 1, distance(g.Player, cr.Pos))
+			}
+		}
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,196 @@
+package game
+
+import (
+	"log"
+
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+const OverviewMenuID = "overview"
+
+// OverviewZooms are the zoom levels of the overview map; the number of tiles
+// along each side of the square of columns shown by a single cell.
+var OverviewZooms = []int{1, 2, 4, 8}
+
+// OverviewPan is the number of cells the overview map moves when panned.
+const OverviewPan = 8
+
+// Column is a summary of a remembered column of tiles, as shown on the
+// overview map and the minimap.
+type Column struct {
+	// Z is the z-level of the highest remembered tile in the column with
+	// something in it.
+	Z int
+
+	// Color is the color of that tile; the color of its liquid, grass, block,
+	// or floor, in that order.
+	Color color.Enum
+
+	// Liquid is true if the tile holds liquid.
+	Liquid bool
+}
+
+// Column returns the summary of the (x,y) column of tiles, or false if no
+// tile of the column with something in it has been seen.
+func (g *Game) Column(x, y int) (Column, bool) {
+	pos := GlobalCoords(x, y, 0)
+	c := g.Chunk(pos.Chunk)
+	if c == nil {
+		return Column{}, false
+	}
+	for z := chunk.Height - 1; z >= 0; z-- {
+		t := c.Recall(pos.X, pos.Y, z)
+		if t == nil || t.SeeThrough() {
+			continue
+		}
+		col := Column{Z: z, Liquid: t.Liquid > 0}
+		switch {
+		case t.Liquid > 0:
+			col.Color = g.Materials[t.LiquidMat].Liquid.Color
+		case t.Flags&tile.HasGrass != 0:
+			col.Color = color.Green
+		case t.Block.Definition != tile.BlockEmpty:
+			col.Color = g.Materials[t.Block.Material].Solid.Color
+		default:
+			col.Color = g.Materials[t.Floor.Material].Solid.Color
+		}
+		return col, true
+	}
+	return Column{}, false
+}
+
+// Summarize returns the summary of the square of size by size columns with
+// its top-left column at (x,y); the highest of the columns which have been
+// seen. If none of the columns have been seen, this returns false.
+func (g *Game) Summarize(x, y, size int) (Column, bool) {
+	best, found := Column{}, false
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			if col, ok := g.Column(x+dx, y+dy); ok && (!found || col.Z > best.Z) {
+				best, found = col, true
+			}
+		}
+	}
+	return best, found
+}
+
+// MapOverview is implemented by menus which show an overview of the world.
+//
+// Drivers should draw the summary of each square of columns around the
+// center of the overview instead of the menu options, and use the map key
+// context while it is shown.
+type MapOverview interface {
+	// GetCenter returns the world-space (x,y) position at the center of the
+	// overview.
+	GetCenter() (int, int)
+	// GetZoom returns the number of tiles along each side of the square of
+	// columns shown by a single cell.
+	GetZoom() int
+}
+
+// OverviewMenu is a menu which shows an overview of the explored world,
+// which may be panned and zoomed.
+type OverviewMenu struct {
+	x, y int
+	zoom int
+}
+
+// NewOverviewMenu returns a new OverviewMenu instance.
+func NewOverviewMenu() *OverviewMenu {
+	return &OverviewMenu{zoom: 1}
+}
+
+// Start centers the overview on the player. The zoom level is kept from the
+// last time the overview was shown.
+func (o *OverviewMenu) Start(app *Application) {
+	log.Printf("game.OverviewMenu::Start(): ID: %q", OverviewMenuID)
+	o.x, o.y = app.Game.Player.Global()
+}
+
+// Stop finalizes the menu.
+func (o *OverviewMenu) Stop(app *Application) {
+	log.Printf("game.OverviewMenu::Stop(): ID: %q", OverviewMenuID)
+}
+
+// Pause pauses the menu.
+func (o *OverviewMenu) Pause(app *Application) {}
+
+// Resume resumes the menu.
+func (o *OverviewMenu) Resume(app *Application) {}
+
+// GetID returns the ID of the menu.
+func (o *OverviewMenu) GetID() string {
+	return OverviewMenuID
+}
+
+// GetTitle returns the title of the menu.
+func (o *OverviewMenu) GetTitle() string {
+	return "World Map"
+}
+
+// GetOptions returns no options, as the overview is drawn instead.
+func (o *OverviewMenu) GetOptions() []string {
+	return nil
+}
+
+// GetOption returns the currently selected option, which is always zero.
+func (o *OverviewMenu) GetOption() int {
+	return 0
+}
+
+// SetOption does nothing, as the menu has no options.
+func (o *OverviewMenu) SetOption(int) {}
+
+// GetCenter returns the world-space position at the center of the overview.
+func (o *OverviewMenu) GetCenter() (int, int) {
+	return o.x, o.y
+}
+
+// GetZoom returns the number of tiles along each side of a cell.
+func (o *OverviewMenu) GetZoom() int {
+	return OverviewZooms[o.zoom]
+}
+
+// HandleAction handles menu actions.
+//
+// Movement actions pan the overview by OverviewPan cells, stopping at the
+// edges of the loaded world.
+func (o *OverviewMenu) HandleAction(a Action, app *Application) RenderRequest {
+	if dx, dy, _, ok := a.Direction(); ok {
+		step := OverviewPan * o.GetZoom()
+		bx, by, bw, bh := app.Game.Bounds()
+		o.x = clamp(o.x+dx*step, bx, bx+bw-1)
+		o.y = clamp(o.y+dy*step, by, by+bh-1)
+		return RenderFull
+	}
+	switch a {
+	case ActionZoomIn:
+		if o.zoom == 0 {
+			return RenderNoChange
+		}
+		o.zoom--
+	case ActionZoomOut:
+		if o.zoom == len(OverviewZooms)-1 {
+			return RenderNoChange
+		}
+		o.zoom++
+	case ActionMenuClose, ActionMenuSelect, ActionOverview:
+		app.PopMenu()
+	default:
+		return RenderNoChange
+	}
+	return RenderFull
+}
+
+// clamp returns v limited to the range [lo, hi].
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverview(t *testing.T) {
+	t.Parallel()
+	t.Run("columns", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.StartGame(app.NewGame)
+		g := app.Game
+
+		px, py := g.Player.Global()
+		col, ok := g.Column(px, py)
+		require.True(t, ok)
+		assert.Equal(t, g.Player.Z, col.Z)
+
+		_, ok = g.Column(px+10000, py)
+		assert.False(t, ok)
+
+		// A summary covers the highest of its columns
+		sum, ok := g.Summarize(px-1, py-1, 3)
+		require.True(t, ok)
+		assert.GreaterOrEqual(t, sum.Z, col.Z)
+	})
+	t.Run("pan and zoom", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.StartGame(app.NewGame)
+		require.Equal(t, RenderFull, app.Update(ActionOverview))
+		o, ok := app.GetMenu().(*OverviewMenu)
+		require.True(t, ok)
+
+		px, py := app.Game.Player.Global()
+		x, y := o.GetCenter()
+		assert.Equal(t, px, x)
+		assert.Equal(t, py, y)
+		zoom := o.GetZoom()
+
+		app.Update(ActionMoveEast)
+		x, _ = o.GetCenter()
+		assert.Equal(t, px+OverviewPan*zoom, x)
+
+		// Panning stops at the edge of the world
+		for i := 0; i < 20; i++ {
+			app.Update(ActionMoveNorth)
+		}
+		_, by, _, _ := app.Game.Bounds()
+		_, y = o.GetCenter()
+		assert.Equal(t, by, y)
+
+		app.Update(ActionZoomOut)
+		assert.Greater(t, o.GetZoom(), zoom)
+		for i := 0; i < len(OverviewZooms); i++ {
+			app.Update(ActionZoomIn)
+		}
+		assert.Equal(t, OverviewZooms[0], o.GetZoom())
+		assert.Equal(t, RenderNoChange, app.Update(ActionZoomIn))
+
+		app.Update(ActionMenuClose)
+		assert.Nil(t, app.GetMenu())
+	})
+}
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"container/heap"
+
+	"github.com/tvarney/grogue/pkg/cerr"
+)
+
+const (
+	// ErrNoPath is returned when no path to the destination exists.
+	ErrNoPath = cerr.Error("no path found")
+
+	// ErrSearchLimit is returned when a path search gives up before finding
+	// the destination.
+	ErrSearchLimit = cerr.Error("path search limit reached")
+)
+
+// DefaultMaxSearch is the number of tiles a path search expands before
+// giving up if PathOptions doesn't set a limit.
+const DefaultMaxSearch = 20000
+
+// PathOptions controls how paths are searched for.
+type PathOptions struct {
+	// MaxSearch is the maximum number of tiles to expand before giving up.
+	//
+	// If this is 0, DefaultMaxSearch is used.
+	MaxSearch int
+
+	// MaxLiquid is the deepest liquid a path may move through.
+	//
+	// If this is 0, paths may not enter tiles containing any liquid.
+	MaxLiquid uint16
+
+	// KnownOnly limits the search to tiles the player remembers.
+	//
+	// A tile the player has never seen may still end the path, but the search
+	// will not continue through it.
+	KnownOnly bool
+}
+
+// FindPath finds the cheapest path from one position to another.
+//
+// The returned path starts with the first tile moved into and ends with the
+// destination. If from and to are the same position, the path is empty.
+func (g *Game) FindPath(from, to Coords, opts PathOptions) ([]Coords, error) {
+	to = to.Offset(0, 0, 0)
+	tx, ty := to.Global()
+	goal := func(c Coords) bool {
+		return c == to
+	}
+	estimate := func(c Coords) int {
+		// Octile distance, or the cost of climbing if that is greater
+		x, y := c.Global()
+		dx, dy := abs(tx-x), abs(ty-y)
+		if dx < dy {
+			dx, dy = dy, dx
+		}
+		d := CostHorizontal*(dx-dy) + CostDiagonal*dy
+		if dz := CostHorizontal * abs(to.Z-c.Z); dz > d {
+			d = dz
+		}
+		return d
+	}
+	return g.search(from, goal, estimate, opts)
+}
+
+// FindNearest finds the cheapest path to the nearest tile matching goal.
+//
+// The returned path starts with the first tile moved into and ends with the
+// tile which matched. If from matches, the path is empty.
+func (g *Game) FindNearest(from Coords, goal func(Coords) bool, opts PathOptions) ([]Coords, error) {
+	return g.search(from, goal, func(Coords) int { return 0 }, opts)
+}
+
+// search implements an A* search; if estimate always returns 0, this is
+// equivalent to Dijkstra's algorithm.
+func (g *Game) search(from Coords, goal func(Coords) bool, estimate func(Coords) int, opts PathOptions) ([]Coords, error) {
+	limit := opts.MaxSearch
+	if limit <= 0 {
+		limit = DefaultMaxSearch
+	}
+	from = from.Offset(0, 0, 0)
+
+	costs := map[Coords]int{from: 0}
+	parents := map[Coords]Coords{}
+	closed := map[Coords]struct{}{}
+	open := &pathQueue{{pos: from, priority: estimate(from)}}
+
+	for open.Len() > 0 {
+		node := heap.Pop(open).(pathNode)
+		if _, done := closed[node.pos]; done {
+			continue
+		}
+		if goal(node.pos) {
+			return buildPath(parents, from, node.pos), nil
+		}
+		closed[node.pos] = struct{}{}
+		if len(closed) >= limit {
+			return nil, ErrSearchLimit
+		}
+		if opts.KnownOnly && node.pos != from && g.Recall(node.pos) == nil {
+			continue
+		}
+
+		for _, s := range Steps {
+			next := node.pos.Offset(s.DX, s.DY, s.DZ)
+			if _, done := closed[next]; done {
+				continue
+			}
+			if !g.CanStep(node.pos, s) || g.Tile(next).Liquid > opts.MaxLiquid {
+				continue
+			}
+			cost := costs[node.pos] + g.MoveCost(node.pos, s)
+			if prev, ok := costs[next]; ok && prev <= cost {
+				continue
+			}
+			costs[next] = cost
+			parents[next] = node.pos
+			heap.Push(open, pathNode{pos: next, priority: cost + estimate(next)})
+		}
+	}
+	return nil, ErrNoPath
+}
+
+func buildPath(parents map[Coords]Coords, from, to Coords) []Coords {
+	path := []Coords{}
+	for c := to; c != from; c = parents[c] {
+		path = append(path, c)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+type pathNode struct {
+	pos      Coords
+	priority int
+}
+
+// pathQueue is a min-heap of path nodes ordered by priority.
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *pathQueue) Push(x interface{}) {
+	*q = append(*q, x.(pathNode))
+}
+
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
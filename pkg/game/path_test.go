@@ -0,0 +1,110 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/chunk"
+)
+
+// findStairs returns the position of the staircase with the given top or
+// bottom in the given chunk.
+func findStairs(tb testing.TB, g *Game, cc ChunkCoords, z int) Coords {
+	tb.Helper()
+	c := g.Chunk(cc)
+	for y := 0; y < chunk.Length; y++ {
+		for x := 0; x < chunk.Width; x++ {
+			t := c.Get(x, y, z)
+			if t.StairsUp() || t.StairsDown() {
+				return Coords{X: x, Y: y, Z: z, Chunk: cc}
+			}
+		}
+	}
+	tb.Fatalf("no stairs at z-level %d of chunk %v", z, cc)
+	return Coords{}
+}
+
+func TestFindPath(t *testing.T) {
+	g := newTestGame(t)
+	g.GenerateWorld()
+
+	t.Run("same", func(t *testing.T) {
+		from := findStairs(t, g, ChunkCoords{}, chunk.SurfaceLevel)
+		path, err := g.FindPath(from, from, PathOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, path)
+	})
+	t.Run("across chunks and levels", func(t *testing.T) {
+		from := findStairs(t, g, ChunkCoords{X: -1, Y: -1}, chunk.SurfaceLevel)
+		to := findStairs(t, g, ChunkCoords{X: 1, Y: 1}, chunk.CaveOffset)
+		path, err := g.FindPath(from, to, PathOptions{})
+		require.NoError(t, err)
+		require.NotEmpty(t, path)
+		assert.Equal(t, to, path[len(path)-1])
+
+		prev := from
+		for _, c := range path {
+			px, py := prev.Global()
+			cx, cy := c.Global()
+			s := Step{DX: cx - px, DY: cy - py, DZ: c.Z - prev.Z}
+			assert.True(t, g.CanStep(prev, s), "step %v from %v", s, prev)
+			prev = c
+		}
+	})
+	t.Run("limit", func(t *testing.T) {
+		from := findStairs(t, g, ChunkCoords{X: -1, Y: -1}, chunk.SurfaceLevel)
+		to := findStairs(t, g, ChunkCoords{X: 1, Y: 1}, chunk.CaveOffset)
+		_, err := g.FindPath(from, to, PathOptions{MaxSearch: 10})
+		assert.ErrorIs(t, err, ErrSearchLimit)
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		from := findStairs(t, g, ChunkCoords{}, chunk.SurfaceLevel)
+		to := Coords{X: 0, Y: 0, Z: 1, Chunk: ChunkCoords{}}
+		_, err := g.FindPath(from, to, PathOptions{})
+		assert.ErrorIs(t, err, ErrNoPath)
+	})
+}
+
+func BenchmarkFindPath(b *testing.B) {
+	g := newTestGame(b)
+	g.GenerateWorld()
+
+	b.Run("cave", func(b *testing.B) {
+		// Find a distant underground tile reachable from the bottom of the
+		// staircase in the center chunk
+		from := findStairs(b, g, ChunkCoords{}, chunk.CaveOffset)
+		fx, fy := from.Global()
+		path, err := g.FindNearest(from, func(c Coords) bool {
+			cx, cy := c.Global()
+			return c.Z < chunk.SurfaceLevel-2 && abs(cx-fx)+abs(cy-fy) >= 16
+		}, PathOptions{})
+		if err != nil {
+			b.Skipf("no distant cave tile reachable: %v", err)
+		}
+		to := path[len(path)-1]
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = g.FindPath(from, to, PathOptions{})
+		}
+	})
+	b.Run("across chunks", func(b *testing.B) {
+		from := findStairs(b, g, ChunkCoords{X: -1, Y: -1}, chunk.SurfaceLevel)
+		to := findStairs(b, g, ChunkCoords{X: 1, Y: 1}, chunk.CaveOffset)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = g.FindPath(from, to, PathOptions{})
+		}
+	})
+	b.Run("nearest", func(b *testing.B) {
+		from := findStairs(b, g, ChunkCoords{}, chunk.CaveOffset)
+		goal := func(c Coords) bool {
+			return c.Chunk != from.Chunk
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = g.FindNearest(from, goal, PathOptions{})
+		}
+	})
+}
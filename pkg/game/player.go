@@ -0,0 +1,217 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/body"
+	"github.com/tvarney/grogue/pkg/game/creature"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const (
+	// PlayerSize is the body size of the player, in kg.
+	PlayerSize = 70
+
+	// DefaultPlayerName is the name of characters which haven't been given
+	// one.
+	DefaultPlayerName = "Adventurer"
+
+	// AverageAttribute is the value of an unremarkable attribute.
+	AverageAttribute = 10
+)
+
+// The number of ticks before the player becomes hungry, thirsty, or tired,
+// and the number before they start to suffer from it.
+const (
+	HungryTicks    = 1500 * TurnTicks
+	StarvingTicks  = 3000 * TurnTicks
+	ThirstyTicks   = 1000 * TurnTicks
+	ParchedTicks   = 2000 * TurnTicks
+	TiredTicks     = 2000 * TurnTicks
+	ExhaustedTicks = 4000 * TurnTicks
+)
+
+const (
+	// RegenTicks is the number of ticks it takes the player to heal a single
+	// point of damage, if they aren't starving or parched.
+	RegenTicks = 20 * TurnTicks
+
+	// SufferTicks is the number of ticks between each point of damage the
+	// player takes from starving or being parched.
+	SufferTicks = 10 * TurnTicks
+)
+
+// Attributes are the natural abilities of a character.
+//
+// Each attribute is AverageAttribute for an unremarkable character.
+type Attributes struct {
+	// Strength increases the damage of attacks.
+	Strength int
+	// Agility increases the chance of attacks hitting, and the speed of the
+	// character.
+	Agility int
+	// Toughness increases how much damage each body part can take.
+	Toughness int
+	// Perception increases how far the character can see.
+	Perception int
+}
+
+// DefaultAttributes returns a set of unremarkable attributes.
+func DefaultAttributes() Attributes {
+	return Attributes{
+		Strength:   AverageAttribute,
+		Agility:    AverageAttribute,
+		Toughness:  AverageAttribute,
+		Perception: AverageAttribute,
+	}
+}
+
+// Character is the state of the player's character.
+type Character struct {
+	Name       string
+	Attributes Attributes
+	Body       *body.Body
+
+	// Hunger, Thirst, and Fatigue are the number of ticks since the
+	// character last ate, drank, and slept.
+	Hunger  int64
+	Thirst  int64
+	Fatigue int64
+
+	// Kills is the number of creatures the character has killed.
+	Kills int
+
+	// Cause is the cause of the character's death, if they have died.
+	Cause string
+
+	regen  int64
+	suffer int64
+}
+
+// Speed returns the speed of the character, relative to
+// creature.NormalSpeed.
+//
+// Exhausted characters move at half speed.
+func (c *Character) Speed() int {
+	speed := creature.NormalSpeed + (c.Attributes.Agility-AverageAttribute)*3
+	if c.Fatigue >= ExhaustedTicks {
+		speed /= 2
+	}
+	return speed
+}
+
+// HitChance returns the percent chance of the character's attacks hitting.
+func (c *Character) HitChance() int {
+	return HitChance + (c.Attributes.Agility-AverageAttribute)*2
+}
+
+// DamageFactor returns the factor the damage of the character's attacks is
+// multiplied by.
+func (c *Character) DamageFactor() float64 {
+	return 1.0 + float64(c.Attributes.Strength-AverageAttribute)*0.05
+}
+
+// ViewRadius returns the distance, in tiles, the character can see.
+func (c *Character) ViewRadius() int {
+	return ViewRadius + (c.Attributes.Perception-AverageAttribute)/2
+}
+
+// HungerStatus returns a description of how hungry the character is, or an
+// empty string if they aren't hungry.
+func (c *Character) HungerStatus() string {
+	return status(c.Hunger, HungryTicks, StarvingTicks, "Hungry", "Starving")
+}
+
+// ThirstStatus returns a description of how thirsty the character is, or an
+// empty string if they aren't thirsty.
+func (c *Character) ThirstStatus() string {
+	return status(c.Thirst, ThirstyTicks, ParchedTicks, "Thirsty", "Parched")
+}
+
+// FatigueStatus returns a description of how tired the character is, or an
+// empty string if they aren't tired.
+func (c *Character) FatigueStatus() string {
+	return status(c.Fatigue, TiredTicks, ExhaustedTicks, "Tired", "Exhausted")
+}
+
+func status(v, low, high int64, lowName, highName string) string {
+	switch {
+	case v >= high:
+		return highName
+	case v >= low:
+		return lowName
+	}
+	return ""
+}
+
+// NewCharacter returns a new character with the given name and attributes,
+// and an unharmed body.
+func (g *Game) NewCharacter(name string, attrs Attributes) Character {
+	size := PlayerSize * float64(attrs.Toughness) / AverageAttribute
+	return Character{
+		Name:       name,
+		Attributes: attrs,
+		Body:       body.New(&g.Plans[body.Humanoid], size),
+	}
+}
+
+// ResetPlayer gives the player a new, unremarkable character and empties
+// their inventory and equipment, ready for a new game.
+func (g *Game) ResetPlayer() {
+	g.Character = g.NewCharacter(DefaultPlayerName, DefaultAttributes())
+	g.Inventory = item.Inventory{}
+	g.Equipment = Equipment{}
+	g.Dead = false
+}
+
+// UpdateCharacter advances the needs of the character by the given number of
+// ticks, healing them or harming them as appropriate.
+func (g *Game) UpdateCharacter(ticks int64) {
+	c := &g.Character
+	before := [3]string{c.HungerStatus(), c.ThirstStatus(), c.FatigueStatus()}
+	c.Hunger += ticks
+	c.Thirst += ticks
+	c.Fatigue += ticks
+	after := [3]string{c.HungerStatus(), c.ThirstStatus(), c.FatigueStatus()}
+	for i := range after {
+		if after[i] != before[i] && after[i] != "" {
+			g.Message(message.Warning, "You are %s.", lower(after[i]))
+		}
+	}
+
+	if c.Hunger >= StarvingTicks || c.Thirst >= ParchedTicks {
+		c.suffer += ticks
+		for ; c.suffer >= SufferTicks; c.suffer -= SufferTicks {
+			c.Body.Hurt(c.Body.Core(), 1)
+		}
+		if c.Body.Dead() {
+			cause := "starvation"
+			if c.Thirst >= ParchedTicks {
+				cause = "thirst"
+			}
+			g.Die(cause)
+		}
+		return
+	}
+	c.regen += ticks
+	for ; c.regen >= RegenTicks; c.regen -= RegenTicks {
+		c.Body.Heal(1)
+	}
+}
+
+// Die ends the player's life; the game is over.
+func (g *Game) Die(cause string) {
+	if g.Dead {
+		return
+	}
+	g.Character.Cause = cause
+	g.Dead = true
+	g.Message(message.Danger, "You die...")
+}
+
+// lower returns s with its first letter in lower case.
+func lower(s string) string {
+	if s == "" || s[0] < 'A' || s[0] > 'Z' {
+		return s
+	}
+	return string(s[0]-'A'+'a') + s[1:]
+}
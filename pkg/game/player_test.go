@@ -0,0 +1,66 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCharacter(t *testing.T) {
+	t.Parallel()
+	t.Run("needs", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.UpdateCharacter(ThirstyTicks)
+		assert.Equal(t, "Thirsty", g.Character.ThirstStatus())
+		assert.Equal(t, "", g.Character.HungerStatus())
+		assert.Equal(t, "You are thirsty.", g.Messages.Last(1)[0].Text)
+	})
+	t.Run("heal", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.Character.Body.Hurt(2, 5)
+		g.UpdateCharacter(5 * RegenTicks)
+		assert.Zero(t, g.Character.Body.Damage[2])
+	})
+	t.Run("starve", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.Character.Hunger = StarvingTicks
+		for i := 0; i < 1000 && !g.Dead; i++ {
+			g.UpdateCharacter(SufferTicks)
+		}
+		assert.True(t, g.Dead)
+		assert.Equal(t, "starvation", g.Character.Cause)
+	})
+}
+
+func TestDeath(t *testing.T) {
+	app := New(1)
+	app.SummaryDir = t.TempDir()
+	app.StartGame(app.NewGame)
+	require.True(t, app.InGame)
+
+	app.Game.Character.Body.Hurt(0, 1000)
+	app.Game.Die("testing")
+	app.Update(ActionWait)
+	require.NotNil(t, app.GetMenu())
+	assert.Equal(t, DeathMenuID, app.GetMenu().GetID())
+
+	files, err := os.ReadDir(app.SummaryDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(filepath.Join(app.SummaryDir, files[0].Name()))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), DefaultPlayerName+" died on turn"))
+	assert.Contains(t, string(data), "Cause of death: testing")
+
+	app.Update(ActionMenuSelect)
+	assert.False(t, app.InGame)
+	require.NotNil(t, app.GetMenu())
+	assert.Equal(t, MainMenuID, app.GetMenu().GetID())
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import "container/heap"
+
+// Scheduler orders the turns of actors by the time they may next act.
+//
+// Time is measured in ticks. Actors are identified by integer IDs; an actor
+// is scheduled to act after a delay, and Next returns the actor whose turn
+// comes first, advancing the current time to that turn. Actors scheduled for
+// the same tick act in the order they were scheduled.
+type Scheduler struct {
+	now     int64
+	seq     uint64
+	queue   queue
+	removed map[int]uint64
+}
+
+// New returns a new, empty Scheduler.
+func New() *Scheduler {
+	return &Scheduler{removed: map[int]uint64{}}
+}
+
+// Now returns the current time, in ticks.
+func (s *Scheduler) Now() int64 {
+	return s.now
+}
+
+// Len returns the number of scheduled turns.
+func (s *Scheduler) Len() int {
+	return s.queue.Len()
+}
+
+// Schedule schedules the actor with the given ID to act after delay ticks.
+//
+// An actor should only have a single turn scheduled at a time.
+func (s *Scheduler) Schedule(id int, delay int64) {
+	if delay < 0 {
+		delay = 0
+	}
+	s.seq++
+	heap.Push(&s.queue, entry{at: s.now + delay, seq: s.seq, id: id})
+}
+
+// Remove cancels any scheduled turn of the actor with the given ID.
+func (s *Scheduler) Remove(id int) {
+	s.removed[id] = s.seq
+}
+
+// Next removes and returns the ID of the actor whose turn is next, advancing
+// the current time to that turn.
+//
+// If no turns are scheduled, this returns false.
+func (s *Scheduler) Next() (int, bool) {
+	for s.queue.Len() > 0 {
+		e := heap.Pop(&s.queue).(entry)
+		if seq, ok := s.removed[e.id]; ok && e.seq <= seq {
+			continue
+		}
+		s.now = e.at
+		return e.id, true
+	}
+	return 0, false
+}
+
+type entry struct {
+	at  int64
+	seq uint64
+	id  int
+}
+
+// queue is a min-heap of turns ordered by time, then by scheduling order.
+type queue []entry
+
+func (q queue) Len() int { return len(q) }
+func (q queue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+func (q queue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *queue) Push(x interface{}) {
+	*q = append(*q, x.(entry))
+}
+
+func (q *queue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,57 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+	t.Run("order", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+		s.Schedule(1, 100)
+		s.Schedule(2, 50)
+		s.Schedule(3, 100)
+
+		ids := []int{}
+		for id, ok := s.Next(); ok; id, ok = s.Next() {
+			ids = append(ids, id)
+		}
+		assert.Equal(t, []int{2, 1, 3}, ids)
+		assert.Equal(t, int64(100), s.Now())
+	})
+	t.Run("relative", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+		s.Schedule(1, 100)
+		id, _ := s.Next()
+		require.Equal(t, 1, id)
+		s.Schedule(1, 100)
+		s.Schedule(2, 50)
+		id, _ = s.Next()
+		assert.Equal(t, 2, id)
+		assert.Equal(t, int64(150), s.Now())
+	})
+	t.Run("remove", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+		s.Schedule(1, 10)
+		s.Schedule(2, 20)
+		s.Remove(1)
+		s.Schedule(3, 30)
+		id, ok := s.Next()
+		require.True(t, ok)
+		assert.Equal(t, 2, id)
+
+		s.Schedule(1, 5)
+		id, _ = s.Next()
+		assert.Equal(t, 1, id)
+		id, _ = s.Next()
+		assert.Equal(t, 3, id)
+		_, ok = s.Next()
+		assert.False(t, ok)
+	})
+}
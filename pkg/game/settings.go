@@ -0,0 +1,269 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const OptionsMenuID = "options"
+
+// The glyph sets drivers may draw the game with.
+const (
+	GlyphsUnicode = "unicode"
+	GlyphsASCII   = "ascii"
+)
+
+// The color modes drivers may draw the game with. ColorAuto lets the driver
+// pick the best mode the terminal supports.
+const (
+	ColorAuto = "auto"
+	ColorTrue = "true"
+	Color256  = "256"
+	Color16   = "16"
+)
+
+// GlyphSets are the glyph sets which may be chosen in the options menu.
+var GlyphSets = []string{GlyphsUnicode, GlyphsASCII}
+
+// ColorModes are the color modes which may be chosen in the options menu.
+var ColorModes = []string{ColorAuto, ColorTrue, Color256, Color16}
+
+// verbosityNames are the names of the message verbosity levels, indexed by
+// the lowest message.Severity shown.
+var verbosityNames = []string{"All messages", "Important", "Warnings only"}
+
+// Settings are user preferences which persist between games.
+type Settings struct {
+	// GlyphSet is the name of the set of glyphs the map is drawn with.
+	GlyphSet string `json:"glyph_set"`
+
+	// ColorMode is the number of colors the driver draws with.
+	ColorMode string `json:"color_mode"`
+
+	// Palette is the name of the palette colors are drawn with; either a
+	// built-in palette or a palette file in the palettes directory.
+	Palette string `json:"palette"`
+
+	// Verbosity is the lowest severity of message which is shown; less
+	// important messages are discarded.
+	Verbosity message.Severity `json:"verbosity"`
+}
+
+// DefaultSettings returns the settings used when the user hasn't chosen any.
+func DefaultSettings() Settings {
+	return Settings{
+		GlyphSet:  GlyphsUnicode,
+		ColorMode: ColorAuto,
+		Palette:   color.PaletteDefault,
+		Verbosity: message.Info,
+	}
+}
+
+// DefaultSettingsPath returns the default path of the settings file; a file
+// under the user's config directory.
+//
+// If the user config directory can't be determined, this returns an empty
+// string.
+func DefaultSettingsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		log.Printf("game::DefaultSettingsPath(): %v", err)
+		return ""
+	}
+	return filepath.Join(dir, "grogue", "settings.json")
+}
+
+// LoadSettings reads settings from the file at the given path.
+//
+// Settings missing from the file keep their default values. If the file
+// doesn't exist, the default settings are returned without an error.
+func LoadSettings(path string) (Settings, error) {
+	s := DefaultSettings()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return DefaultSettings(), fmt.Errorf("invalid settings file %q: %w", path, err)
+	}
+	if indexOf(GlyphSets, s.GlyphSet) < 0 {
+		s.GlyphSet = GlyphsUnicode
+	}
+	if indexOf(ColorModes, s.ColorMode) < 0 {
+		s.ColorMode = ColorAuto
+	}
+	if s.Palette == "" {
+		s.Palette = color.PaletteDefault
+	}
+	if int(s.Verbosity) >= len(verbosityNames) {
+		s.Verbosity = message.Info
+	}
+	return s, nil
+}
+
+// Save writes the settings to the file at the given path, creating the
+// directory holding it if needed.
+func (s *Settings) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ApplySettings updates the game to reflect the current settings.
+func (a *Application) ApplySettings() {
+	a.Game.Verbosity = a.Settings.Verbosity
+	a.Palette = a.LoadPalette(a.Settings.Palette)
+}
+
+// PalettesDir returns the directory palette files are loaded from; the
+// palettes directory next to the settings file.
+//
+// If SettingsPath isn't set, this returns an empty string.
+func (a *Application) PalettesDir() string {
+	if a.SettingsPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(a.SettingsPath), "palettes")
+}
+
+// PaletteNames returns the names of the palettes which may be chosen; the
+// built-in palettes, followed by the palette files in PalettesDir.
+func (a *Application) PaletteNames() []string {
+	names := append([]string{}, color.BuiltinPalettes...)
+	if dir := a.PalettesDir(); dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		for _, f := range files {
+			name := strings.TrimSuffix(filepath.Base(f), ".json")
+			if indexOf(names, name) < 0 {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// LoadPalette returns the named palette; a built-in palette, or the palette
+// file with that name in PalettesDir.
+//
+// If the palette can't be loaded, the default palette is returned.
+func (a *Application) LoadPalette(name string) color.Palette {
+	if p, ok := color.BuiltinPalette(name); ok {
+		return p
+	}
+	if dir := a.PalettesDir(); dir != "" {
+		p, err := color.LoadPalette(filepath.Join(dir, name+".json"))
+		if err == nil {
+			return p
+		}
+		log.Printf("game.Application::LoadPalette(): %v", err)
+	}
+	log.Printf("game.Application::LoadPalette(): Using the default palette instead of %q", name)
+	return color.DefaultPalette()
+}
+
+// SaveSettings writes the current settings to SettingsPath, if it is set.
+func (a *Application) SaveSettings() {
+	if a.SettingsPath == "" {
+		return
+	}
+	if err := a.Settings.Save(a.SettingsPath); err != nil {
+		log.Printf("game.Application::SaveSettings(): Failed to save settings: %v", err)
+	}
+}
+
+// NewOptionsMenu returns a new StaticMenu instance for changing the settings.
+//
+// Each setting is changed with the left and right actions, or by selecting
+// it. The settings are saved when the menu is closed.
+func NewOptionsMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    OptionsMenuID,
+		Title: "Options",
+	}
+	refresh := func(app *Application) {
+		s := &app.Settings
+		m.Options = []string{
+			"Glyphs:   " + s.GlyphSet,
+			"Colors:   " + s.ColorMode,
+			"Palette:  " + s.Palette,
+			"Messages: " + verbosityNames[s.Verbosity],
+			"Key Bindings",
+			"Back",
+		}
+	}
+	adjust := func(change func(s *Settings, delta int)) func(*Application, int) RenderRequest {
+		return func(app *Application, delta int) RenderRequest {
+			change(&app.Settings, delta)
+			app.ApplySettings()
+			refresh(app)
+			return RenderFull
+		}
+	}
+	m.Adjust = []func(*Application, int) RenderRequest{
+		adjust(func(s *Settings, delta int) {
+			s.GlyphSet = GlyphSets[wrap(indexOf(GlyphSets, s.GlyphSet)+delta, len(GlyphSets))]
+		}),
+		adjust(func(s *Settings, delta int) {
+			s.ColorMode = ColorModes[wrap(indexOf(ColorModes, s.ColorMode)+delta, len(ColorModes))]
+		}),
+		func(app *Application, delta int) RenderRequest {
+			names := app.PaletteNames()
+			app.Settings.Palette = names[wrap(indexOf(names, app.Settings.Palette)+delta, len(names))]
+			app.ApplySettings()
+			refresh(app)
+			return RenderFull
+		},
+		adjust(func(s *Settings, delta int) {
+			s.Verbosity = message.Severity(wrap(int(s.Verbosity)+delta, len(verbosityNames)))
+		}),
+	}
+	m.Actions = make([]func(*Application) RenderRequest, len(m.Adjust), len(m.Adjust)+2)
+	for i := range m.Adjust {
+		f := m.Adjust[i]
+		m.Actions[i] = func(app *Application) RenderRequest {
+			return f(app, 1)
+		}
+	}
+	m.Actions = append(m.Actions,
+		func(app *Application) RenderRequest {
+			app.PushMenu(KeyContextsMenuID)
+			return RenderFull
+		},
+		func(app *Application) RenderRequest {
+			app.PopMenu()
+			return RenderFull
+		},
+	)
+	m.OnStart = refresh
+	m.OnResume = refresh
+	m.OnStop = func(app *Application) {
+		app.SaveSettings()
+	}
+	return m
+}
+
+// indexOf returns the index of s in values, or -1 if it isn't present.
+func indexOf(values []string, s string) int {
+	for i, v := range values {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
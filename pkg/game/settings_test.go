@@ -0,0 +1,99 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvarney/grogue/pkg/game/color"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+		s, err := LoadSettings(filepath.Join(t.TempDir(), "settings.json"))
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSettings(), s)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "grogue", "settings.json")
+		s := Settings{GlyphSet: GlyphsASCII, ColorMode: Color16, Palette: color.PaletteProtanopia, Verbosity: message.Warning}
+		require.NoError(t, s.Save(path))
+		loaded, err := LoadSettings(path)
+		require.NoError(t, err)
+		assert.Equal(t, s, loaded)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "settings.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"glyph_set": "runes", "color_mode": "256"}`), 0o644))
+		s, err := LoadSettings(path)
+		require.NoError(t, err)
+		assert.Equal(t, GlyphsUnicode, s.GlyphSet)
+		assert.Equal(t, Color256, s.ColorMode)
+
+		require.NoError(t, os.WriteFile(path, []byte(`{`), 0o644))
+		s, err = LoadSettings(path)
+		assert.Error(t, err)
+		assert.Equal(t, DefaultSettings(), s)
+	})
+	t.Run("menu", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.SettingsPath = filepath.Join(t.TempDir(), "settings.json")
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuSelect)
+		require.Equal(t, OptionsMenuID, app.GetMenu().GetID())
+
+		app.Update(ActionMenuRight)
+		assert.Equal(t, GlyphsASCII, app.Settings.GlyphSet)
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuLeft)
+		assert.Equal(t, Color16, app.Settings.ColorMode)
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuRight)
+		assert.Equal(t, color.PaletteHighContrast, app.Settings.Palette)
+		assert.Equal(t, color.HighContrastPalette(), app.Palette)
+		app.Update(ActionMenuDown)
+		app.Update(ActionMenuSelect)
+		assert.Equal(t, message.Good, app.Game.Verbosity)
+		assert.Equal(t, "Messages: Important", app.GetMenu().GetOptions()[3])
+
+		app.Update(ActionMenuClose)
+		loaded, err := LoadSettings(app.SettingsPath)
+		require.NoError(t, err)
+		assert.Equal(t, app.Settings, loaded)
+	})
+	t.Run("palette files", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		app.SettingsPath = filepath.Join(t.TempDir(), "settings.json")
+		require.NoError(t, os.MkdirAll(app.PalettesDir(), 0o755))
+		path := filepath.Join(app.PalettesDir(), "dusk.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"colors": {"red": "#AA2222"}}`), 0o644))
+		assert.Equal(t, append(color.BuiltinPalettes, "dusk"), app.PaletteNames())
+
+		app.Settings.Palette = "dusk"
+		app.ApplySettings()
+		assert.Equal(t, uint32(0xAA2222), app.Palette.Value(color.Red))
+
+		app.Settings.Palette = "missing"
+		app.ApplySettings()
+		assert.Equal(t, color.DefaultPalette(), app.Palette)
+	})
+	t.Run("verbosity", func(t *testing.T) {
+		t.Parallel()
+		g := newTestGame(t)
+		g.Verbosity = message.Warning
+		g.Message(message.Info, "ignored")
+		g.Message(message.Danger, "shown")
+		require.Equal(t, 1, g.Messages.Len())
+		assert.Equal(t, "shown", g.Messages.Last(1)[0].Text)
+	})
+}
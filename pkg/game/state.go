@@ -1,8 +1,17 @@
 package game
 
 import (
+	"math/rand"
+
+	"github.com/tvarney/grogue/pkg/game/body"
 	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/craft"
+	"github.com/tvarney/grogue/pkg/game/creature"
+	"github.com/tvarney/grogue/pkg/game/item"
+	"github.com/tvarney/grogue/pkg/game/light"
 	"github.com/tvarney/grogue/pkg/game/material"
+	"github.com/tvarney/grogue/pkg/game/message"
+	"github.com/tvarney/grogue/pkg/game/schedule"
 	"github.com/tvarney/grogue/pkg/game/tile"
 )
 
@@ -17,11 +26,49 @@ const (
 )
 
 type Game struct {
-	Materials []*material.Material
-	Blocks    []tile.Definition
-	Floors    []tile.Definition
+	Materials   []*material.Material
+	Blocks      []tile.Definition
+	Floors      []tile.Definition
+	Recipes     []craft.Recipe
+	Backgrounds []Background
+	Species     []creature.Species
+	Plans       []body.Plan
+	Messages    *message.Log
+	Verbosity   message.Severity
 
 	Player       Coords
-	ActiveChunks [9]*chunk.Chunk
+	PlayerLight  light.Level
+	Character    Character
+	Inventory    item.Inventory
+	Equipment    Equipment
+	Dead         bool
+	View         View
+	Cursor       Cursor
+	MapView      MapView
+	Auto         AutoMove
+	Light        *light.Map
+	Seed         int64
+	WorldRadius  int
+	ActiveChunks []*chunk.Chunk
 	Generator    *chunk.Generator
+	Creatures    []*Creature
+	Turns        *schedule.Scheduler
+	Rand         *rand.Rand
+
+	lastCreatureID int
+	lastSpawn      int64
+}
+
+// Message adds a message to the message log.
+//
+// Any automatic movement of the player is interrupted, giving them the chance
+// to react to the message. Messages less severe than Verbosity are discarded,
+// but still interrupt automatic movement, as automatic movement ends by
+// posting a message.
+func (g *Game) Message(sev message.Severity, format string, args ...interface{}) {
+	g.Interrupt()
+	if sev < g.Verbosity {
+		return
+	}
+	g.Messages.Addf(sev, format, args...)
 }
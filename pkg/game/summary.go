@@ -0,0 +1,125 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tvarney/grogue/pkg/game/chunk"
+)
+
+const (
+	DeathMenuID = "death"
+
+	// summaryMessages is the number of recent messages included in the
+	// summary of a game.
+	summaryMessages = 10
+)
+
+// DefaultSummaryDir returns the default directory game summaries are written
+// to; a directory under the user's config directory.
+//
+// If the user config directory can't be determined, this returns an empty
+// string.
+func DefaultSummaryDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		log.Printf("game::DefaultSummaryDir(): %v", err)
+		return ""
+	}
+	return filepath.Join(dir, "grogue", "summaries")
+}
+
+// Summary returns the lines of a summary of the player's game.
+func (g *Game) Summary() []string {
+	c := &g.Character
+	lines := []string{
+		fmt.Sprintf("%s died on turn %d.", c.Name, g.Turns.Now()/TurnTicks),
+		fmt.Sprintf("Cause of death: %s", c.Cause),
+		fmt.Sprintf("Depth: %d", chunk.SurfaceLevel-g.Player.Z),
+		fmt.Sprintf("Creatures killed: %d", c.Kills),
+		fmt.Sprintf(
+			"Strength %d, Agility %d, Toughness %d, Perception %d",
+			c.Attributes.Strength, c.Attributes.Agility, c.Attributes.Toughness, c.Attributes.Perception,
+		),
+	}
+	names := make([]string, 0, g.Inventory.Len())
+	for i := range g.Inventory.Items {
+		names = append(names, g.Inventory.Items[i].Name(g.Materials))
+	}
+	if len(names) == 0 {
+		names = append(names, "nothing")
+	}
+	lines = append(lines, "Carrying: "+strings.Join(names, ", "), "", "Last messages:")
+	for _, m := range g.Messages.Last(summaryMessages) {
+		lines = append(lines, "  "+m.String())
+	}
+	return lines
+}
+
+// WriteSummary writes the summary of the player's game to a new file in the
+// given directory, returning the path of the file.
+func (g *Game) WriteSummary(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s.txt", strings.ReplaceAll(g.Character.Name, " ", "_"), time.Now().Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	data := strings.Join(g.Summary(), "\n") + "\n"
+	return path, os.WriteFile(path, []byte(data), 0o644)
+}
+
+// EndGame ends the game after the player's death, writing a summary of the
+// game and showing it in the death menu.
+func (a *Application) EndGame() {
+	log.Printf("game.Application::EndGame(): Player died; %s", a.Game.Character.Cause)
+	a.Game.Interrupt()
+	a.Game.CloseCursor()
+	if a.SummaryDir != "" {
+		if path, err := a.Game.WriteSummary(a.SummaryDir); err != nil {
+			log.Printf("game.Application::EndGame(): Failed to write summary: %v", err)
+		} else {
+			log.Printf("game.Application::EndGame(): Wrote summary to %q", path)
+		}
+	}
+	for a.GetMenu() != nil {
+		a.PopMenu()
+	}
+	a.PushMenu(DeathMenuID)
+}
+
+// ReturnToMainMenu leaves the game, showing the main menu.
+func (a *Application) ReturnToMainMenu() {
+	a.InGame = false
+	for a.GetMenu() != nil {
+		a.PopMenu()
+	}
+	a.PushMenu(MainMenuID)
+}
+
+// NewDeathMenu returns a new StaticMenu instance showing the summary of the
+// player's game.
+//
+// Selecting any line of the summary returns to the main menu.
+func NewDeathMenu() *StaticMenu {
+	m := &StaticMenu{
+		ID:    DeathMenuID,
+		Title: "You have died",
+	}
+	m.OnStart = func(app *Application) {
+		back := func(app *Application) RenderRequest {
+			app.ReturnToMainMenu()
+			return RenderFull
+		}
+		m.Options = append(app.Game.Summary(), "", "Return to Main Menu")
+		m.Actions = make([]func(*Application) RenderRequest, len(m.Options))
+		for i := range m.Actions {
+			m.Actions[i] = back
+		}
+		m.SetOption(len(m.Options) - 1)
+	}
+	return m
+}
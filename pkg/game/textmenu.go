@@ -0,0 +1,182 @@
+package game
+
+import (
+	"log"
+	"unicode"
+)
+
+// TextEntry is implemented by menus which accept text typed by the player.
+//
+// Drivers should pass printable characters to Insert instead of translating
+// them to actions. Editing keys are still sent as actions; ActionMenuLeft and
+// ActionMenuRight move the cursor, and ActionMenuBackspace, ActionMenuDelete,
+// ActionMenuHome, and ActionMenuEnd edit the text as expected.
+type TextEntry interface {
+	// GetText returns the text entered so far.
+	GetText() string
+	// GetCursor returns the position of the cursor, in runes.
+	GetCursor() int
+	// GetError returns the reason the text was last rejected, or an empty
+	// string if it wasn't.
+	GetError() string
+	// Insert inserts a character at the cursor, returning false if the
+	// character isn't allowed.
+	Insert(rune) bool
+}
+
+// TextMenu is a menu which asks the player to type a line of text.
+type TextMenu struct {
+	ID     string
+	Title  string
+	Prompt string
+
+	// MaxLength is the maximum number of characters which may be entered. If
+	// this is zero or less, the length isn't limited.
+	MaxLength int
+
+	// Allow returns true if the character may be typed. If this is nil, any
+	// printable character is allowed.
+	Allow func(rune) bool
+
+	// Validate checks the text when the player selects it, returning an error
+	// describing the problem if the text isn't acceptable.
+	Validate func(string) error
+
+	// OnStart returns the initial text of the menu.
+	OnStart func(*Application) string
+
+	// OnAccept is called with the text once it has been validated.
+	OnAccept func(*Application, string) RenderRequest
+
+	text   []rune
+	cursor int
+	err    string
+}
+
+// Start initializes the menu for display, resetting the text.
+func (t *TextMenu) Start(app *Application) {
+	log.Printf("game.TextMenu::Start(): ID: %q", t.ID)
+	t.text = nil
+	if t.OnStart != nil {
+		t.text = []rune(t.OnStart(app))
+	}
+	t.cursor = len(t.text)
+	t.err = ""
+}
+
+// Stop finalizes the menu.
+func (t *TextMenu) Stop(app *Application) {
+	log.Printf("game.TextMenu::Stop(): ID: %q", t.ID)
+}
+
+// Pause pauses the menu.
+func (t *TextMenu) Pause(app *Application) {}
+
+// Resume resumes the menu.
+func (t *TextMenu) Resume(app *Application) {}
+
+// GetID returns the ID of the menu.
+func (t *TextMenu) GetID() string {
+	return t.ID
+}
+
+// GetTitle returns the title of the menu.
+func (t *TextMenu) GetTitle() string {
+	return t.Title
+}
+
+// GetOptions returns the prompt and text of the menu as a single option, for
+// drivers which don't support text entry.
+func (t *TextMenu) GetOptions() []string {
+	return []string{t.Prompt + string(t.text)}
+}
+
+// GetOption returns the currently selected option, which is always the
+// text.
+func (t *TextMenu) GetOption() int {
+	return 0
+}
+
+// SetOption does nothing, as the menu has a single option.
+func (t *TextMenu) SetOption(int) {}
+
+// GetText returns the text entered so far.
+func (t *TextMenu) GetText() string {
+	return string(t.text)
+}
+
+// GetCursor returns the position of the cursor, in runes.
+func (t *TextMenu) GetCursor() int {
+	return t.cursor
+}
+
+// GetError returns the reason the text was last rejected.
+func (t *TextMenu) GetError() string {
+	return t.err
+}
+
+// Insert inserts a character at the cursor.
+//
+// This returns false if the character isn't allowed, or if the text is
+// already at the maximum length.
+func (t *TextMenu) Insert(r rune) bool {
+	if !unicode.IsPrint(r) || (t.Allow != nil && !t.Allow(r)) {
+		return false
+	}
+	if t.MaxLength > 0 && len(t.text) >= t.MaxLength {
+		return false
+	}
+	t.text = append(t.text, 0)
+	copy(t.text[t.cursor+1:], t.text[t.cursor:])
+	t.text[t.cursor] = r
+	t.cursor++
+	t.err = ""
+	return true
+}
+
+// HandleAction handles menu actions.
+func (t *TextMenu) HandleAction(a Action, app *Application) RenderRequest {
+	switch a {
+	case ActionMenuLeft:
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case ActionMenuRight:
+		if t.cursor < len(t.text) {
+			t.cursor++
+		}
+	case ActionMenuHome:
+		t.cursor = 0
+	case ActionMenuEnd:
+		t.cursor = len(t.text)
+	case ActionMenuBackspace:
+		if t.cursor == 0 {
+			return RenderNoChange
+		}
+		t.text = append(t.text[:t.cursor-1], t.text[t.cursor:]...)
+		t.cursor--
+	case ActionMenuDelete:
+		if t.cursor == len(t.text) {
+			return RenderNoChange
+		}
+		t.text = append(t.text[:t.cursor], t.text[t.cursor+1:]...)
+	case ActionMenuSelect:
+		text := string(t.text)
+		if t.Validate != nil {
+			if err := t.Validate(text); err != nil {
+				t.err = err.Error()
+				return RenderFull
+			}
+		}
+		t.err = ""
+		if t.OnAccept != nil {
+			return t.OnAccept(app, text)
+		}
+		app.PopMenu()
+	case ActionMenuClose:
+		app.PopMenu()
+	default:
+		return RenderNoChange
+	}
+	return RenderFull
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextMenu(t *testing.T) {
+	t.Parallel()
+	t.Run("editing", func(t *testing.T) {
+		t.Parallel()
+		m := &TextMenu{ID: "text", Prompt: "> "}
+		m.Start(nil)
+		for _, r := range "hllo" {
+			assert.True(t, m.Insert(r))
+		}
+		m.HandleAction(ActionMenuHome, nil)
+		m.HandleAction(ActionMenuRight, nil)
+		m.Insert('e')
+		assert.Equal(t, "hello", m.GetText())
+		assert.Equal(t, 2, m.GetCursor())
+
+		m.HandleAction(ActionMenuBackspace, nil)
+		m.HandleAction(ActionMenuDelete, nil)
+		assert.Equal(t, "hlo", m.GetText())
+		assert.Equal(t, 1, m.GetCursor())
+
+		m.HandleAction(ActionMenuEnd, nil)
+		m.HandleAction(ActionMenuRight, nil)
+		assert.Equal(t, 3, m.GetCursor())
+		assert.Equal(t, []string{"> hlo"}, m.GetOptions())
+		assert.False(t, m.Insert('\n'))
+	})
+	t.Run("limits", func(t *testing.T) {
+		t.Parallel()
+		m := &TextMenu{
+			ID:        "text",
+			MaxLength: 3,
+			Allow:     func(r rune) bool { return r != 'x' },
+		}
+		m.Start(nil)
+		assert.False(t, m.Insert('x'))
+		for _, r := range "abcd" {
+			m.Insert(r)
+		}
+		assert.Equal(t, "abc", m.GetText())
+	})
+	t.Run("validate", func(t *testing.T) {
+		t.Parallel()
+		app := New(1)
+		accepted := ""
+		app.AddMenu(&TextMenu{
+			ID: "text",
+			Validate: func(s string) error {
+				if s == "" {
+					return ErrEmptyName
+				}
+				return nil
+			},
+			OnAccept: func(app *Application, s string) RenderRequest {
+				accepted = s
+				app.PopMenu()
+				return RenderFull
+			},
+		})
+		app.PushMenu("text")
+		app.Update(ActionMenuSelect)
+		entry := app.GetMenu().(TextEntry)
+		assert.Equal(t, ErrEmptyName.Error(), entry.GetError())
+		entry.Insert('a')
+		assert.Empty(t, entry.GetError())
+		app.Update(ActionMenuSelect)
+		assert.Equal(t, "a", accepted)
+		assert.Equal(t, MainMenuID, app.GetMenu().GetID())
+	})
+}
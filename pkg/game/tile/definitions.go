@@ -6,6 +6,10 @@ const (
 	BlockSoil
 	BlockRoughWall
 	BlockSmoothWall
+	BlockStairsUp
+	BlockStairsDown
+	BlockStairsUpDown
+	BlockRamp
 )
 
 const (
@@ -24,6 +28,10 @@ func DefaultDefinitions() ([]Definition, []Definition) {
 		{ID: "block-soil", Name: "{{.Solid.Name}}"},
 		{ID: "block-wall-rough", Name: "rough {{.Solid.Adjective}} wall"},
 		{ID: "block-wall-smooth", Name: "smooth {{.Solid.Adjective}} wall"},
+		{ID: "block-stairs-up", Name: "{{.Solid.Adjective}} up staircase"},
+		{ID: "block-stairs-down", Name: "{{.Solid.Adjective}} down staircase"},
+		{ID: "block-stairs-updown", Name: "{{.Solid.Adjective}} up/down staircase"},
+		{ID: "block-ramp", Name: "{{.Solid.Adjective}} ramp"},
 	}
 	floors := []Definition{
 		{ID: "floor-empty", Name: "empty"},
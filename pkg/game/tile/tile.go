@@ -10,6 +10,9 @@ import (
 
 const (
 	HasGrass StateFlags = 1 << iota
+
+	// Remembered marks a tile state as a remembered copy of a tile.
+	Remembered
 )
 
 // ID is the tile-definition ID for a tile-state.
@@ -77,3 +80,34 @@ func (s *State) Describe(blocks, floors []Definition, mats []*material.Material)
 	}
 	return "empty"
 }
+
+// Passable returns true if the block of the tile may be moved through.
+//
+// Empty tiles, stairs, and ramps are passable; all other blocks are solid.
+func (s *State) Passable() bool {
+	switch s.Block.Definition {
+	case BlockEmpty, BlockStairsUp, BlockStairsDown, BlockStairsUpDown, BlockRamp:
+		return true
+	}
+	return false
+}
+
+// Opaque returns true if the tile blocks line of sight.
+func (s *State) Opaque() bool {
+	return !s.Passable()
+}
+
+// StairsUp returns true if the tile has stairs leading to the level above.
+func (s *State) StairsUp() bool {
+	return s.Block.Definition == BlockStairsUp || s.Block.Definition == BlockStairsUpDown
+}
+
+// StairsDown returns true if the tile has stairs leading to the level below.
+func (s *State) StairsDown() bool {
+	return s.Block.Definition == BlockStairsDown || s.Block.Definition == BlockStairsUpDown
+}
+
+// SeeThrough returns true if tiles below this one may be seen through it.
+func (s *State) SeeThrough() bool {
+	return s.Block.Definition == BlockEmpty && s.Floor.Definition == FloorEmpty && s.Liquid == 0
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/creature"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
+
+const (
+	// PlayerID is the ID of the player's turns in the turn scheduler.
+	PlayerID = 0
+
+	// TurnTicks is the number of ticks a creature at normal speed takes to
+	// move to an adjacent tile.
+	TurnTicks = 100
+)
+
+// Ticks returns the number of ticks an action of the given movement cost
+// takes a creature of the given speed.
+func Ticks(cost, speed int) int64 {
+	if speed <= 0 {
+		speed = creature.NormalSpeed
+	}
+	return int64(cost) * TurnTicks * creature.NormalSpeed / (CostHorizontal * int64(speed))
+}
+
+// EndTurn ends the player's turn, which took the given number of ticks.
+//
+// Every creature whose turn comes before the player's next turn acts, after
+// which the player is told about any creatures which came into view. Each
+// creature bleeds at the start of its turn, and the player bleeds and their
+// needs are updated at the end of theirs.
+func (g *Game) EndTurn(ticks int64) {
+	if g.bleed(fighter{}); g.Dead {
+		return
+	}
+	if g.UpdateCharacter(ticks); g.Dead {
+		return
+	}
+	g.Turns.Schedule(PlayerID, ticks)
+	for !g.Dead {
+		id, ok := g.Turns.Next()
+		if !ok || id == PlayerID {
+			break
+		}
+		cr := g.creature(id)
+		if cr == nil {
+			continue
+		}
+		if g.bleed(fighter{creature: cr}); cr.Body.Dead() {
+			continue
+		}
+		cost := g.Act(cr)
+		g.Turns.Schedule(cr.ID, Ticks(cost, g.SpeciesOf(cr).Speed))
+	}
+	g.spawnOverTime()
+	g.noticeCreatures()
+}
+
+// noticeCreatures tells the player about each creature which has come into
+// view since the end of the last turn.
+func (g *Game) noticeCreatures() {
+	for _, cr := range g.Creatures {
+		seen := g.IsVisible(cr.Pos)
+		if seen && !cr.Seen {
+			sp := g.SpeciesOf(cr)
+			if sp.Behavior == creature.Hunt {
+				g.Message(message.Warning, "You see a %s!", sp.Name)
+			} else {
+				g.Message(message.Info, "You see a %s.", sp.Name)
+			}
+		}
+		cr.Seen = seen
+	}
+}
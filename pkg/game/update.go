@@ -1,77 +1,75 @@
 package game
 
-import "github.com/tvarney/grogue/pkg/game/chunk"
+import (
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/message"
+)
 
 // UpdateMovePlayer implements player movement.
 //
-// This function takes a delta-x, delta-y, and delta-z value; these values
-// are assumed to be one of -1, 0, or 1. All other values are not handled
-// (yet).
+// This function takes a delta-x, delta-y, and delta-z value. Moves to an
+// adjacent tile follow the movement rules of CanStep; if a horizontal move
+// can't be made on the current level, moving up or down a ramp in that
+// direction is tried instead. Longer moves only require the destination to
+// be standable.
 func (a *Application) UpdateMovePlayer(dx, dy, dz int) RenderRequest {
-	ret := RenderNoChange
+	g := a.Game
+	to := g.Player.Offset(dx, dy, dz)
+	if to.Z < 0 || to.Z >= chunk.Height || g.Chunk(to.Chunk) == nil {
+		g.Message(message.Info, "You can't go any further that way.")
+		return RenderIncremental
+	}
 
-	switch dx {
-	case -1:
-		if a.Game.Player.X == 0 {
-			if a.Game.Player.Chunk.X != -1 {
-				a.Game.Player.X = chunk.Width - 1
-				a.Game.Player.Chunk.X--
-				ret = RenderIncremental
-			}
-		} else {
-			a.Game.Player.X--
-			ret = RenderIncremental
+	cost := CostHorizontal
+	if abs(dx) > 1 || abs(dy) > 1 || abs(dz) > 1 {
+		if !g.Standable(to) {
+			return RenderNoChange
 		}
-	case 1:
-		if a.Game.Player.X == chunk.Width-1 {
-			if a.Game.Player.Chunk.X != 1 {
-				a.Game.Player.X = 0
-				a.Game.Player.Chunk.X++
-				ret = RenderIncremental
-			}
-		} else {
-			a.Game.Player.X++
-			ret = RenderIncremental
+	} else if s := (Step{DX: dx, DY: dy, DZ: dz}); !g.CanStep(g.Player, s) {
+		switch {
+		case dz > 0:
+			g.Message(message.Info, "You can't go up here.")
+			return RenderIncremental
+		case dz < 0:
+			g.Message(message.Info, "You can't go down here.")
+			return RenderIncremental
+		case g.CanStep(g.Player, Step{DX: dx, DY: dy, DZ: 1}):
+			to = to.Offset(0, 0, 1)
+		case g.CanStep(g.Player, Step{DX: dx, DY: dy, DZ: -1}):
+			to = to.Offset(0, 0, -1)
+		default:
+			g.blocked(to)
+			return RenderIncremental
 		}
 	}
 
-	switch dy {
-	case -1:
-		if a.Game.Player.Y == 0 {
-			if a.Game.Player.Chunk.Y != -1 {
-				a.Game.Player.Y = chunk.Length - 1
-				a.Game.Player.Chunk.Y--
-				ret = RenderIncremental
-			}
-		} else {
-			a.Game.Player.Y--
-			ret = RenderIncremental
-		}
-	case 1:
-		if a.Game.Player.Y == chunk.Width-1 {
-			if a.Game.Player.Chunk.Y != 1 {
-				a.Game.Player.Y = 0
-				a.Game.Player.Chunk.Y++
-				ret = RenderIncremental
-			}
-		} else {
-			a.Game.Player.Y++
-			ret = RenderIncremental
+	if cr := g.CreatureAt(to); cr != nil {
+		// Don't start fights while moving automatically
+		if g.Auto.Mode != AutoNone {
+			g.Message(message.Info, "There is a %s in the way.", g.SpeciesOf(cr).Name)
+			return RenderIncremental
 		}
+		g.PlayerAttack(cr)
+		g.EndTurn(TurnTicks)
+		return RenderIncremental
 	}
 
-	switch dz {
-	case -1:
-		if a.Game.Player.Z > 0 {
-			a.Game.Player.Z--
-			ret = RenderIncremental
-		}
-	case 1:
-		if a.Game.Player.Z < chunk.Height-1 {
-			a.Game.Player.Z++
-			ret = RenderIncremental
-		}
+	px, py := g.Player.Global()
+	tx, ty := to.Global()
+	if s := (Step{DX: tx - px, DY: ty - py, DZ: to.Z - g.Player.Z}); abs(s.DX) <= 1 && abs(s.DY) <= 1 && abs(s.DZ) <= 1 {
+		cost = g.MoveCost(g.Player, s)
 	}
+	g.Player = to
+	g.UpdateView()
+	g.EndTurn(Ticks(cost, g.Character.Speed()))
+	return RenderIncremental
+}
 
-	return ret
+// blocked tells the player that they can't move into the tile at c.
+func (g *Game) blocked(c Coords) {
+	if t := g.Tile(c); t != nil && !t.Passable() && g.IsVisible(c) {
+		g.Message(message.Info, "There is %s in the way.", t.Describe(g.Blocks, g.Floors, g.Materials))
+		return
+	}
+	g.Message(message.Info, "You can't move there.")
 }
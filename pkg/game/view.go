@@ -0,0 +1,104 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/fov"
+)
+
+// ViewRadius is the maximum distance, in tiles, a character of average
+// perception can see.
+const ViewRadius = 20
+
+// ViewDepth is the number of levels below the player the player can see down
+// through open air.
+const ViewDepth = 6
+
+// MapView is an enumeration of the ways the map may be drawn.
+type MapView int
+
+const (
+	// MapTop draws a single z-level of the map from above, looking down
+	// through open air to the levels below it.
+	MapTop MapView = iota
+
+	// MapSide draws a vertical cross-section of the map along the x-axis,
+	// showing the z-levels above and below the player.
+	MapSide
+)
+
+// View is the set of tile columns visible to the player.
+//
+// Visibility is calculated on the z-level the player is on; a tile below that
+// level is visible if its column is visible, it is no more than ViewDepth
+// levels down, and every tile between it and the view level can be seen
+// through.
+type View struct {
+	Z int
+
+	visible map[[2]int]struct{}
+}
+
+// viewMap adapts a Game to the fov.Map interface for a single z-level.
+type viewMap struct {
+	game *Game
+	z    int
+}
+
+func (m viewMap) BlocksSight(x, y int) bool {
+	t := m.game.Tile(GlobalCoords(x, y, m.z))
+	return t == nil || t.Opaque()
+}
+
+// IsVisible returns true if the tile at the given coordinates is currently
+// visible to the player.
+func (g *Game) IsVisible(c Coords) bool {
+	if c.Z > g.View.Z || c.Z < g.View.Z-ViewDepth {
+		return false
+	}
+	x, y := c.Global()
+	if _, ok := g.View.visible[[2]int{x, y}]; !ok {
+		return false
+	}
+	for z := g.View.Z; z > c.Z; z-- {
+		t := g.Tile(Coords{X: c.X, Y: c.Y, Z: z, Chunk: c.Chunk})
+		if t == nil || !t.SeeThrough() {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateView recalculates the set of tiles visible to the player, and
+// updates the remembered appearance of those tiles.
+//
+// This also updates the light map, as tiles which are not lit are not
+// visible.
+func (g *Game) UpdateView() {
+	g.UpdateLight()
+
+	px, py := g.Player.Global()
+	g.View.Z = g.Player.Z
+	g.View.visible = make(map[[2]int]struct{}, len(g.View.visible))
+	fov.Compute(viewMap{game: g, z: g.Player.Z}, px, py, g.Character.ViewRadius(), func(x, y int) {
+		pos := GlobalCoords(x, y, g.Player.Z)
+		c := g.Chunk(pos.Chunk)
+		if c == nil || g.Light.Get(x, y) == 0 {
+			return
+		}
+		g.View.visible[[2]int{x, y}] = struct{}{}
+
+		// Remember the tile, along with the tiles below it which can be seen
+		c.Remember(pos.X, pos.Y, pos.Z)
+		for z := pos.Z; z > 0 && z > pos.Z-ViewDepth && c.Get(pos.X, pos.Y, z).SeeThrough(); z-- {
+			c.Remember(pos.X, pos.Y, z-1)
+		}
+	})
+}
+
+// ToggleMapView switches the map between the top and side views.
+func (g *Game) ToggleMapView() {
+	if g.MapView == MapSide {
+		g.MapView = MapTop
+		return
+	}
+	g.MapView = MapSide
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"github.com/tvarney/grogue/pkg/game/chunk"
+	"github.com/tvarney/grogue/pkg/game/schedule"
+	"github.com/tvarney/grogue/pkg/game/tile"
+)
+
+// DefaultWorldRadius is the radius, in chunks, of the world if no other size
+// is chosen.
+const DefaultWorldRadius = 1
+
+// chunkIndex returns the index of the chunk at the given chunk coordinates in
+// ActiveChunks, or -1 if the coordinates are outside of the world.
+func (g *Game) chunkIndex(cc ChunkCoords) int {
+	r := g.WorldRadius
+	if cc.X < -r || cc.X > r || cc.Y < -r || cc.Y > r {
+		return -1
+	}
+	return (cc.X + r) + (cc.Y+r)*(2*r+1)
+}
+
+// Chunk returns the active chunk at the given chunk coordinates.
+//
+// If the chunk is not loaded, this returns nil.
+func (g *Game) Chunk(cc ChunkCoords) *chunk.Chunk {
+	idx := g.chunkIndex(cc)
+	if idx < 0 || idx >= len(g.ActiveChunks) {
+		return nil
+	}
+	return g.ActiveChunks[idx]
+}
+
+// ChunkCoordsList returns the coordinates of every chunk in the world.
+func (g *Game) ChunkCoordsList() []ChunkCoords {
+	r := g.WorldRadius
+	list := make([]ChunkCoords, 0, (2*r+1)*(2*r+1))
+	for cy := -r; cy <= r; cy++ {
+		for cx := -r; cx <= r; cx++ {
+			list = append(list, ChunkCoords{X: cx, Y: cy})
+		}
+	}
+	return list
+}
+
+// GenerateWorld generates each of the chunks within WorldRadius of the
+// center of the world, along with the creatures living in them.
+func (g *Game) GenerateWorld() {
+	g.Creatures = nil
+	g.Turns = schedule.New()
+	g.lastSpawn = 0
+	list := g.ChunkCoordsList()
+	g.ActiveChunks = make([]*chunk.Chunk, len(list))
+	for _, cc := range list {
+		g.ActiveChunks[g.chunkIndex(cc)] = g.Generator.Generate(int64(cc.X), int64(cc.Y))
+		g.SpawnCreatures(cc)
+	}
+}
+
+// PlacePlayer moves the player to the dry surface tile nearest to the center
+// of the world.
+func (g *Game) PlacePlayer() {
+	center := Coords{X: chunk.Width / 2, Y: chunk.Length / 2, Z: chunk.SurfaceLevel}
+	g.Player = center
+	for r := 0; r < chunk.Width; r++ {
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if abs(dx) != r && abs(dy) != r {
+					continue
+				}
+				c := center.Offset(dx, dy, 0)
+				if g.Standable(c) && g.Tile(c).Liquid == 0 && g.CreatureAt(c) == nil {
+					g.Player = c
+					return
+				}
+			}
+		}
+	}
+}
+
+// Bounds returns the world-space region covered by the loaded chunks.
+//
+// The returned values are the (x,y) position of the first tile of the region
+// and the width and length of the region.
+func (g *Game) Bounds() (int, int, int, int) {
+	r := g.WorldRadius
+	return -r * chunk.Width, -r * chunk.Length, (2*r + 1) * chunk.Width, (2*r + 1) * chunk.Length
+}
+
+// Tile returns the tile at the given coordinates.
+//
+// If the coordinates fall outside of the loaded chunks, or outside of the
+// valid z range, this returns nil.
+func (g *Game) Tile(c Coords) *tile.State {
+	if c.Z < 0 || c.Z >= chunk.Height {
+		return nil
+	}
+	ch := g.Chunk(c.Chunk)
+	if ch == nil {
+		return nil
+	}
+	return ch.Get(c.X, c.Y, c.Z)
+}
+
+// Recall returns the remembered appearance of the tile at the given
+// coordinates.
+//
+// If the tile has never been seen, this returns nil.
+func (g *Game) Recall(c Coords) *tile.State {
+	if c.Z < 0 || c.Z >= chunk.Height {
+		return nil
+	}
+	ch := g.Chunk(c.Chunk)
+	if ch == nil {
+		return nil
+	}
+	return ch.Recall(c.X, c.Y, c.Z)
+}